@@ -0,0 +1,209 @@
+package trie
+
+// AlphabetMap is a bucket array over the symbols of an alphabet, ordered
+// by their position in the alphabet string rather than by byte value.
+type AlphabetMap[V any] struct {
+	*alphabet
+	buckets []*TrieNode[V]
+}
+
+type alphabet struct {
+	symbols []byte
+	// index[k] is the position of k in symbols plus one, 0 when absent
+	index [256]uint8
+}
+
+// NewAlphabetMap returns a container factory for keys over alphabet, e.g.
+// base32 Crockford, base58 or base62. The alphabet must hold distinct
+// symbols; Pad returns its first one.
+func NewAlphabetMap[V any](symbols string) func() Container[V] {
+	a := newAlphabet(symbols, false)
+	return func() Container[V] {
+		return &AlphabetMap[V]{
+			alphabet: a,
+			buckets:  make([]*TrieNode[V], len(a.symbols)),
+		}
+	}
+}
+
+// FoldAlphabetMap is an AlphabetMap that also accepts the other case of
+// its letters, sharing the bucket of the symbol in the alphabet.
+type FoldAlphabetMap[V any] struct {
+	AlphabetMap[V]
+}
+
+// NewFoldAlphabetMap is like NewAlphabetMap, but folds case. The alphabet
+// must not hold both cases of a letter.
+func NewFoldAlphabetMap[V any](symbols string) func() Container[V] {
+	a := newAlphabet(symbols, true)
+	return func() Container[V] {
+		return &FoldAlphabetMap[V]{AlphabetMap[V]{
+			alphabet: a,
+			buckets:  make([]*TrieNode[V], len(a.symbols)),
+		}}
+	}
+}
+
+func newAlphabet(symbols string, fold bool) *alphabet {
+	if len(symbols) == 0 || len(symbols) > 255 {
+		panic("alphabet size must be 1-255")
+	}
+	var a = &alphabet{symbols: []byte(symbols)}
+	for i, k := range a.symbols {
+		if a.index[k] != 0 {
+			panic("duplicate symbol in alphabet")
+		}
+		a.index[k] = uint8(i + 1)
+	}
+	if fold {
+		for i, k := range a.symbols {
+			o := otherCase(k)
+			if o == k {
+				continue
+			}
+			if a.index[o] != 0 {
+				panic("alphabet holds both cases of a symbol")
+			}
+			a.index[o] = uint8(i + 1)
+		}
+	}
+	return a
+}
+
+func otherCase(k byte) byte {
+	switch {
+	case k >= 'a' && k <= 'z':
+		return k - 'a' + 'A'
+	case k >= 'A' && k <= 'Z':
+		return k - 'A' + 'a'
+	}
+	return k
+}
+
+// Order returns the position of k in the alphabet.
+func (a *alphabet) Order(k byte) int {
+	return int(a.index[k]) - 1
+}
+
+func (m *AlphabetMap[V]) Set(k byte, v *TrieNode[V]) {
+	i := m.Order(k)
+	for j := i - 1; j >= 0; j-- {
+		if m.buckets[j] != nil {
+			m.buckets[j].next = v
+			v.prev = m.buckets[j]
+			break
+		}
+	}
+	for j := i + 1; j < len(m.buckets); j++ {
+		if m.buckets[j] != nil {
+			m.buckets[j].prev = v
+			v.next = m.buckets[j]
+			break
+		}
+	}
+	m.buckets[i] = v
+}
+
+func (m *AlphabetMap[V]) Get(k byte) (*TrieNode[V], bool) {
+	if m == nil || m.index[k] == 0 {
+		return nil, false
+	}
+	v := m.buckets[m.Order(k)]
+	return v, v != nil
+}
+
+func (m *AlphabetMap[V]) Del(k byte) bool {
+	v, ok := m.Get(k)
+	if !ok {
+		return false
+	}
+	if v.prev != nil {
+		v.prev.next = v.next
+	}
+	if v.next != nil {
+		v.next.prev = v.prev
+	}
+	m.buckets[m.Order(k)] = nil
+	v.Free()
+	return true
+}
+
+func (m *AlphabetMap[V]) Prev(k byte) *TrieNode[V] {
+	if m == nil {
+		return nil
+	}
+	for j := m.Order(k) - 1; j >= 0; j-- {
+		if m.buckets[j] != nil {
+			return m.buckets[j]
+		}
+	}
+	return nil
+}
+
+func (m *AlphabetMap[V]) Next(k byte) *TrieNode[V] {
+	if m == nil {
+		return nil
+	}
+	for j := m.Order(k) + 1; j < len(m.buckets); j++ {
+		if m.buckets[j] != nil {
+			return m.buckets[j]
+		}
+	}
+	return nil
+}
+
+func (m *AlphabetMap[V]) Head() *TrieNode[V] {
+	if m == nil {
+		return nil
+	}
+	for _, v := range m.buckets {
+		if v != nil {
+			return v
+		}
+	}
+	return nil
+}
+
+func (m *AlphabetMap[V]) Tail() *TrieNode[V] {
+	if m == nil {
+		return nil
+	}
+	for j := len(m.buckets) - 1; j >= 0; j-- {
+		if v := m.buckets[j]; v != nil {
+			return v
+		}
+	}
+	return nil
+}
+
+func (m *AlphabetMap[V]) Keys() []byte {
+	if m == nil {
+		return nil
+	}
+	var keys = make([]byte, 0, len(m.buckets))
+	for j, v := range m.buckets {
+		if v != nil {
+			keys = append(keys, m.symbols[j])
+		}
+	}
+	return keys
+}
+
+func (m *AlphabetMap[V]) Pad() byte {
+	return m.symbols[0]
+}
+
+func (m *AlphabetMap[V]) Accept(k byte) bool {
+	return m.index[k] != 0
+}
+
+func (m *AlphabetMap[V]) Clear() {
+	clear(m.buckets)
+}
+
+func (m *FoldAlphabetMap[V]) Fold(k byte) byte {
+	if i := m.index[k]; i != 0 {
+		return m.symbols[i-1]
+	}
+	return k
+}
@@ -0,0 +1,294 @@
+package trie
+
+// ArtMap is an adaptive radix tree node. It starts as a sorted array of 4
+// children and grows to 16, 48 and 256 slots as children are added,
+// shrinking again after deletes. The kind is told by len(m.nodes):
+//
+//	4, 16: keys[:size] sorted, nodes[i] is the child of keys[i]
+//	48:    index[k] is the slot of k in nodes plus one, 0 when absent
+//	256:   nodes[k] is the child of k
+type ArtMap[V any] struct {
+	size  int
+	keys  []byte
+	index []uint8
+	nodes []*TrieNode[V]
+}
+
+func NewArtMap[V any]() Container[V] {
+	return &ArtMap[V]{
+		keys:  make([]byte, 4),
+		nodes: make([]*TrieNode[V], 4),
+	}
+}
+
+// slot returns the position of k in nodes, or -1.
+func (m *ArtMap[V]) slot(k byte) int {
+	switch len(m.nodes) {
+	case 256:
+		if m.nodes[k] != nil {
+			return int(k)
+		}
+	case 48:
+		if i := m.index[k]; i != 0 {
+			return int(i) - 1
+		}
+	default:
+		for i := 0; i < m.size; i++ {
+			if m.keys[i] == k {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func (m *ArtMap[V]) Set(k byte, v *TrieNode[V]) {
+	if i := m.slot(k); i >= 0 {
+		return
+	}
+	prev := m.Prev(k)
+	next := m.Next(k)
+	if prev != nil {
+		prev.next = v
+		v.prev = prev
+	}
+	if next != nil {
+		next.prev = v
+		v.next = next
+	}
+	if m.size == len(m.nodes) {
+		m.grow()
+	}
+	switch len(m.nodes) {
+	case 256:
+		m.nodes[k] = v
+	case 48:
+		for i, node := range m.nodes {
+			if node == nil {
+				m.nodes[i] = v
+				m.index[k] = uint8(i + 1)
+				break
+			}
+		}
+	default:
+		i := m.size
+		for i > 0 && m.keys[i-1] > k {
+			m.keys[i] = m.keys[i-1]
+			m.nodes[i] = m.nodes[i-1]
+			i--
+		}
+		m.keys[i] = k
+		m.nodes[i] = v
+	}
+	m.size++
+}
+
+func (m *ArtMap[V]) grow() {
+	switch len(m.nodes) {
+	case 4:
+		keys := make([]byte, 16)
+		nodes := make([]*TrieNode[V], 16)
+		copy(keys, m.keys)
+		copy(nodes, m.nodes)
+		m.keys, m.nodes = keys, nodes
+	case 16:
+		m.index = make([]uint8, 256)
+		nodes := make([]*TrieNode[V], 48)
+		for i := 0; i < m.size; i++ {
+			m.index[m.keys[i]] = uint8(i + 1)
+			nodes[i] = m.nodes[i]
+		}
+		m.keys, m.nodes = nil, nodes
+	case 48:
+		nodes := make([]*TrieNode[V], 256)
+		for k, i := range m.index {
+			if i != 0 {
+				nodes[k] = m.nodes[i-1]
+			}
+		}
+		m.index, m.nodes = nil, nodes
+	}
+}
+
+// shrink moves to the next smaller kind once size has dropped well below
+// its capacity, so that alternating Set and Del do not thrash.
+func (m *ArtMap[V]) shrink() {
+	switch {
+	case len(m.nodes) == 256 && m.size <= 36:
+		m.index = make([]uint8, 256)
+		nodes := make([]*TrieNode[V], 48)
+		var j int
+		for k, node := range m.nodes {
+			if node != nil {
+				m.index[k] = uint8(j + 1)
+				nodes[j] = node
+				j++
+			}
+		}
+		m.nodes = nodes
+	case len(m.nodes) == 48 && m.size <= 12:
+		keys := make([]byte, 16)
+		nodes := make([]*TrieNode[V], 16)
+		var j int
+		for k, i := range m.index {
+			if i != 0 {
+				keys[j] = byte(k)
+				nodes[j] = m.nodes[i-1]
+				j++
+			}
+		}
+		m.index, m.keys, m.nodes = nil, keys, nodes
+	case len(m.nodes) == 16 && m.size <= 3:
+		keys := make([]byte, 4)
+		nodes := make([]*TrieNode[V], 4)
+		copy(keys, m.keys[:m.size])
+		copy(nodes, m.nodes[:m.size])
+		m.keys, m.nodes = keys, nodes
+	}
+}
+
+func (m *ArtMap[V]) Get(k byte) (*TrieNode[V], bool) {
+	if i := m.slot(k); i >= 0 {
+		return m.nodes[i], true
+	}
+	return nil, false
+}
+
+func (m *ArtMap[V]) Del(k byte) bool {
+	i := m.slot(k)
+	if i < 0 {
+		return false
+	}
+	v := m.nodes[i]
+	if v.prev != nil {
+		v.prev.next = v.next
+	}
+	if v.next != nil {
+		v.next.prev = v.prev
+	}
+	switch len(m.nodes) {
+	case 256:
+		m.nodes[i] = nil
+	case 48:
+		m.nodes[i] = nil
+		m.index[k] = 0
+	default:
+		copy(m.keys[i:], m.keys[i+1:m.size])
+		copy(m.nodes[i:], m.nodes[i+1:m.size])
+		m.nodes[m.size-1] = nil
+	}
+	m.size--
+	v.Free()
+	m.shrink()
+	return true
+}
+
+// at returns the child of k in the 48 and 256 kinds.
+func (m *ArtMap[V]) at(k int) *TrieNode[V] {
+	if len(m.nodes) == 256 {
+		return m.nodes[k]
+	}
+	if i := m.index[k]; i != 0 {
+		return m.nodes[i-1]
+	}
+	return nil
+}
+
+func (m *ArtMap[V]) Prev(k byte) *TrieNode[V] {
+	if len(m.nodes) < 48 {
+		for i := m.size - 1; i >= 0; i-- {
+			if m.keys[i] < k {
+				return m.nodes[i]
+			}
+		}
+		return nil
+	}
+	for i := int(k) - 1; i >= 0; i-- {
+		if v := m.at(i); v != nil {
+			return v
+		}
+	}
+	return nil
+}
+
+func (m *ArtMap[V]) Next(k byte) *TrieNode[V] {
+	if len(m.nodes) < 48 {
+		for i := 0; i < m.size; i++ {
+			if m.keys[i] > k {
+				return m.nodes[i]
+			}
+		}
+		return nil
+	}
+	for i := int(k) + 1; i < 256; i++ {
+		if v := m.at(i); v != nil {
+			return v
+		}
+	}
+	return nil
+}
+
+func (m *ArtMap[V]) Head() *TrieNode[V] {
+	if m.size == 0 {
+		return nil
+	}
+	if len(m.nodes) < 48 {
+		return m.nodes[0]
+	}
+	for i := 0; i < 256; i++ {
+		if v := m.at(i); v != nil {
+			return v
+		}
+	}
+	return nil
+}
+
+func (m *ArtMap[V]) Tail() *TrieNode[V] {
+	if m.size == 0 {
+		return nil
+	}
+	if len(m.nodes) < 48 {
+		return m.nodes[m.size-1]
+	}
+	for i := 255; i >= 0; i-- {
+		if v := m.at(i); v != nil {
+			return v
+		}
+	}
+	return nil
+}
+
+func (m *ArtMap[V]) Keys() []byte {
+	var keys = make([]byte, 0, m.size)
+	if len(m.nodes) < 48 {
+		return append(keys, m.keys[:m.size]...)
+	}
+	for i := 0; i < 256; i++ {
+		if m.at(i) != nil {
+			keys = append(keys, byte(i))
+		}
+	}
+	return keys
+}
+
+func (m *ArtMap[V]) Pad() byte {
+	return 0
+}
+
+func (m *ArtMap[V]) Accept(k byte) bool {
+	return true
+}
+
+// Clear empties the map and goes back to 4 slots, so that a container
+// reused after Reset does not keep the size of its old node.
+func (m *ArtMap[V]) Clear() {
+	if len(m.nodes) == 4 {
+		clear(m.keys)
+		clear(m.nodes)
+	} else {
+		m.keys = make([]byte, 4)
+		m.nodes = make([]*TrieNode[V], 4)
+		m.index = nil
+	}
+	m.size = 0
+}
@@ -1,16 +1,16 @@
 package trie
 
-type ByteMap struct {
-	buckets []*TrieNode
+type ByteMap[V any] struct {
+	buckets []*TrieNode[V]
 }
 
-func NewByteMap() Container {
-	return &ByteMap{
-		buckets: make([]*TrieNode, 256),
+func NewByteMap[V any]() Container[V] {
+	return &ByteMap[V]{
+		buckets: make([]*TrieNode[V], 256),
 	}
 }
 
-func (m *ByteMap) Set(k byte, v *TrieNode) {
+func (m *ByteMap[V]) Set(k byte, v *TrieNode[V]) {
 	for i := k - 1; i < k; i-- {
 		if m.buckets[i] != nil {
 			m.buckets[i].next = v
@@ -27,11 +27,11 @@ func (m *ByteMap) Set(k byte, v *TrieNode) {
 	}
 	m.buckets[k] = v
 }
-func (m *ByteMap) Get(k byte) (*TrieNode, bool) {
+func (m *ByteMap[V]) Get(k byte) (*TrieNode[V], bool) {
 	v := m.buckets[k]
 	return v, v != nil
 }
-func (m *ByteMap) Del(k byte) bool {
+func (m *ByteMap[V]) Del(k byte) bool {
 	v := m.buckets[k]
 	defer func() {
 		v.Free()
@@ -52,7 +52,7 @@ func (m *ByteMap) Del(k byte) bool {
 	return false
 }
 
-func (m *ByteMap) Prev(k byte) *TrieNode {
+func (m *ByteMap[V]) Prev(k byte) *TrieNode[V] {
 	for i := k - 1; i < k; i-- {
 		if m.buckets[i] != nil {
 			return m.buckets[i]
@@ -60,7 +60,7 @@ func (m *ByteMap) Prev(k byte) *TrieNode {
 	}
 	return nil
 }
-func (m *ByteMap) Next(k byte) *TrieNode {
+func (m *ByteMap[V]) Next(k byte) *TrieNode[V] {
 	for i := k + 1; i > k; i++ {
 		if m.buckets[i] != nil {
 			return m.buckets[i]
@@ -68,7 +68,7 @@ func (m *ByteMap) Next(k byte) *TrieNode {
 	}
 	return nil
 }
-func (m *ByteMap) Head() *TrieNode {
+func (m *ByteMap[V]) Head() *TrieNode[V] {
 	for i := 0; i < 256; i++ {
 		if v := m.buckets[byte(i)]; v != nil {
 			return v
@@ -76,7 +76,7 @@ func (m *ByteMap) Head() *TrieNode {
 	}
 	return nil
 }
-func (m *ByteMap) Tail() *TrieNode {
+func (m *ByteMap[V]) Tail() *TrieNode[V] {
 	for i := 255; i >= 0; i-- {
 		if v := m.buckets[byte(i)]; v != nil {
 			return v
@@ -84,7 +84,7 @@ func (m *ByteMap) Tail() *TrieNode {
 	}
 	return nil
 }
-func (m *ByteMap) Keys() []byte {
+func (m *ByteMap[V]) Keys() []byte {
 	var keys = make([]byte, 0, 256)
 	for i, v := range m.buckets {
 		if v != nil {
@@ -94,6 +94,14 @@ func (m *ByteMap) Keys() []byte {
 	return keys
 }
 
-func (m *ByteMap) Pad() byte {
+func (m *ByteMap[V]) Pad() byte {
 	return 0
 }
+
+func (m *ByteMap[V]) Accept(k byte) bool {
+	return true
+}
+
+func (m *ByteMap[V]) Clear() {
+	clear(m.buckets)
+}
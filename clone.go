@@ -0,0 +1,54 @@
+package trie
+
+// Clone returns a deep copy of the trie. Values are copied by assignment,
+// keys are shared since the trie never modifies them.
+func (t *Trie[V]) Clone() *Trie[V] {
+	return t.CloneWith(nil)
+}
+
+// CloneWith is like Clone, but copies every value with copyVal, so that
+// mutable values can be deep-copied too.
+func (t *Trie[V]) CloneWith(copyVal func(V) V) *Trie[V] {
+	c := &Trie[V]{
+		keySize:   t.keySize,
+		container: t.container,
+		size:      t.size,
+		version:   t.version,
+		options:   t.options,
+	}
+	c.root = c.cloneNode(t.root, copyVal)
+	return c
+}
+
+// cloneNode copies the subtree of src in key order, appending its entries
+// to the leaf list of t.
+func (t *Trie[V]) cloneNode(src *TrieNode[V], copyVal func(V) V) *TrieNode[V] {
+	node := &TrieNode[V]{
+		nodeKey: src.nodeKey,
+		edgeLen: src.edgeLen,
+		key:     src.key,
+		val:     src.val,
+		hasVal:  src.hasVal,
+		version: src.version,
+		count:   src.count,
+	}
+	if node.hasVal {
+		if copyVal != nil {
+			node.val = copyVal(src.val)
+		}
+		node.prevLeaf = t.tail
+		if t.tail != nil {
+			t.tail.nextLeaf = node
+		} else {
+			t.head = node
+		}
+		t.tail = node
+	}
+	if src.children != nil {
+		node.children = t.container()
+		for c := src.children.Head(); c != nil; c = src.children.Next(c.nodeKey) {
+			node.children.Set(c.nodeKey, t.cloneNode(c, copyVal))
+		}
+	}
+	return node
+}
@@ -0,0 +1,55 @@
+package trie
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestClone(t *testing.T) {
+	var trie = NewVarTrie(NewLinkmap[[]int])
+	for _, k := range []string{"", "a", "ab", "abc", "b", "ba", "c"} {
+		trie.Set([]byte(k), []int{len(k)})
+	}
+	var c = trie.Clone()
+	var d = trie.CloneWith(func(v []int) []int {
+		return append([]int(nil), v...)
+	})
+	c.Del([]byte("ab"))
+	c.Set([]byte("bb"), []int{2})
+	c.DeletePrefix([]byte("c"))
+	d.Set([]byte("d"), []int{1})
+	v, _ := d.Get([]byte("abc"))
+	v[0] = 42
+
+	var keys = func(t *Trie[[]int]) string {
+		var keys []string
+		for k := range t.All() {
+			keys = append(keys, string(k))
+		}
+		return fmt.Sprintf("%d%q", t.Len(), keys)
+	}
+	if s := keys(trie); s != `7["" "a" "ab" "abc" "b" "ba" "c"]` {
+		t.Logf("original: %s fails\n", s)
+		t.Fail()
+	}
+	if s := keys(c); s != `6["" "a" "abc" "b" "ba" "bb"]` {
+		t.Logf("clone: %s fails\n", s)
+		t.Fail()
+	}
+	if s := keys(d); s != `8["" "a" "ab" "abc" "b" "ba" "c" "d"]` {
+		t.Logf("clone with: %s fails\n", s)
+		t.Fail()
+	}
+	if v, _ := trie.Get([]byte("abc")); v[0] != 3 {
+		t.Logf("value %d should be copied\n", v[0])
+		t.Fail()
+	}
+	if key, _, _ := c.Select(4); string(key) != "ba" || c.Rank([]byte("bb")) != 5 {
+		t.Logf("clone select: %s fails\n", key)
+		t.Fail()
+	}
+	if key, _, _ := trie.Last(); string(key) != "c" {
+		t.Logf("original last: %s fails\n", key)
+		t.Fail()
+	}
+}
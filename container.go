@@ -1,13 +1,27 @@
 package trie
 
-type Container interface {
-	Set(k byte, v *TrieNode)
-	Get(k byte) (*TrieNode, bool)
+type Container[V any] interface {
+	Set(k byte, v *TrieNode[V])
+	Get(k byte) (*TrieNode[V], bool)
 	Del(k byte) bool
-	Prev(k uint8) *TrieNode
-	Next(k byte) *TrieNode
-	Head() *TrieNode
-	Tail() *TrieNode
+	Prev(k uint8) *TrieNode[V]
+	Next(k byte) *TrieNode[V]
+	Head() *TrieNode[V]
+	Tail() *TrieNode[V]
 	Keys() []byte
 	Pad() byte
+	Accept(k byte) bool
+	Clear()
+}
+
+// symbolOrder is implemented by containers whose symbols do not sort by
+// byte value, Order returns the position of a symbol.
+type symbolOrder interface {
+	Order(k byte) int
+}
+
+// symbolFolder is implemented by containers that let equivalent symbols
+// share a bucket, Fold returns the canonical form of a symbol.
+type symbolFolder interface {
+	Fold(k byte) byte
 }
@@ -1,14 +1,16 @@
 package trie
 
 import (
+	"bytes"
+	"math/rand"
 	"testing"
 )
 
 func TestHexmap(t *testing.T) {
-	var m = NewHexMap()
+	var m = NewHexMap[byte]()
 	var keys = []byte("0123456789abcdef")
 	for _, k := range keys {
-		m.Set(k, newTrieNode(k, NewHexMap))
+		m.Set(k, newTrieNodeLeaf(k, []byte{k}, k))
 	}
 	for _, k := range keys {
 		v, ok := m.Get(k)
@@ -21,7 +23,7 @@ func TestHexmap(t *testing.T) {
 			t.Logf("key:%v-%v fail\n", k, v.nodeKey)
 			t.Fail()
 		}
-		if k != v.val.(byte) {
+		if k != v.val {
 			t.Logf("val:%v-%v fail\n", k, v.val)
 			t.Fail()
 		}
@@ -33,10 +35,10 @@ func TestHexmap(t *testing.T) {
 }
 
 func TestNmap(t *testing.T) {
-	var m = NewNmap()
+	var m = NewNmap[byte]()
 	var keys = []byte("0123456789")
 	for _, k := range keys {
-		m.Set(k, newTrieNode(k, NewNmap))
+		m.Set(k, newTrieNodeLeaf(k, []byte{k}, k))
 	}
 	for _, k := range keys {
 		v, ok := m.Get(k)
@@ -49,7 +51,7 @@ func TestNmap(t *testing.T) {
 			t.Logf("key:%v-%v fail\n", k, v.nodeKey)
 			t.Fail()
 		}
-		if k != v.val.(byte) {
+		if k != v.val {
 			t.Logf("val:%v-%v fail\n", k, v.val)
 			t.Fail()
 		}
@@ -61,13 +63,13 @@ func TestNmap(t *testing.T) {
 }
 
 func TestLinkmap(t *testing.T) {
-	var m = NewLinkmap()
+	var m = NewLinkmap[byte]()
 	var keys = make([]byte, 0, 256)
 	for i := 0; i < 256; i++ {
 		keys = append(keys, byte(i))
 	}
 	for _, k := range keys {
-		m.Set(k, newTrieNode(k, NewLinkmap))
+		m.Set(k, newTrieNodeLeaf(k, []byte{k}, k))
 	}
 	for _, k := range keys {
 		v, ok := m.Get(k)
@@ -80,7 +82,7 @@ func TestLinkmap(t *testing.T) {
 			t.Logf("key:%v-%v fail\n", k, v.nodeKey)
 			t.Fail()
 		}
-		if k != v.val.(byte) {
+		if k != v.val {
 			t.Logf("val:%v-%v fail\n", k, v.val)
 			t.Fail()
 		}
@@ -92,13 +94,13 @@ func TestLinkmap(t *testing.T) {
 }
 
 func TestBytemap(t *testing.T) {
-	var m = NewByteMap()
+	var m = NewByteMap[byte]()
 	var keys = make([]byte, 0, 256)
 	for i := 0; i < 256; i++ {
 		keys = append(keys, byte(i))
 	}
 	for _, k := range keys {
-		m.Set(k, newTrieNode(k, NewByteMap))
+		m.Set(k, newTrieNodeLeaf(k, []byte{k}, k))
 	}
 	for _, k := range keys {
 		v, ok := m.Get(k)
@@ -111,7 +113,7 @@ func TestBytemap(t *testing.T) {
 			t.Logf("key:%v-%v fail\n", k, v.nodeKey)
 			t.Fail()
 		}
-		if k != v.val.(byte) {
+		if k != v.val {
 			t.Logf("val:%v-%v fail\n", k, v.val)
 			t.Fail()
 		}
@@ -121,3 +123,227 @@ func TestBytemap(t *testing.T) {
 		t.Fail()
 	}
 }
+
+func TestAccept(t *testing.T) {
+	var containers = map[string]struct {
+		m       Container[byte]
+		symbols string
+	}{
+		"hexmap": {NewHexMap[byte](), "0123456789abcdef"},
+		"nmap":   {NewNmap[byte](), "0123456789"},
+	}
+	for name, c := range containers {
+		for i := 0; i < 256; i++ {
+			k := byte(i)
+			want := bytes.IndexByte([]byte(c.symbols), k) >= 0
+			if c.m.Accept(k) != want {
+				t.Logf("%s accept %q: %v fails\n", name, k, !want)
+				t.Fail()
+			}
+		}
+	}
+}
+
+func TestArtmap(t *testing.T) {
+	var m = NewArtMap[byte]()
+	var keys = make([]byte, 0, 256)
+	for i := 0; i < 256; i++ {
+		keys = append(keys, byte(i))
+	}
+	for _, k := range keys {
+		m.Set(k, newTrieNodeLeaf(k, []byte{k}, k))
+	}
+	for _, k := range keys {
+		v, ok := m.Get(k)
+		if !ok {
+			t.Logf("no key:%v fail\n", k)
+			t.Fail()
+			continue
+		}
+		if k != v.nodeKey {
+			t.Logf("key:%v-%v fail\n", k, v.nodeKey)
+			t.Fail()
+		}
+		if k != v.val {
+			t.Logf("val:%v-%v fail\n", k, v.val)
+			t.Fail()
+		}
+	}
+	if ok, i := SliceEq(keys, m.Keys()); !ok {
+		t.Logf("key %d:%v\n%v fails\n", i, keys, m.Keys())
+		t.Fail()
+	}
+}
+
+func TestArtmapGrow(t *testing.T) {
+	var m = NewArtMap[byte]().(*ArtMap[byte])
+	var model = make(map[byte]bool)
+	var kinds = make(map[int]bool)
+	for round := 0; round < 20000; round++ {
+		k := byte(rand.Intn(256))
+		// grow towards 256 children in the first half, shrink in the second
+		if (rand.Intn(10) < 7) == (round < 10000) {
+			m.Set(k, newTrieNodeLeaf(k, []byte{k}, k))
+			model[k] = true
+		} else if m.Del(k) != model[k] {
+			t.Logf("del %d fails\n", k)
+			t.Fail()
+		} else {
+			delete(model, k)
+		}
+		kinds[len(m.nodes)] = true
+		if m.size != len(model) {
+			t.Logf("size %d-%d fails\n", m.size, len(model))
+			t.FailNow()
+		}
+	}
+	for _, kind := range []int{4, 16, 48, 256} {
+		if !kinds[kind] {
+			t.Logf("kind %d not reached\n", kind)
+			t.Fail()
+		}
+	}
+	var keys = m.Keys()
+	if len(keys) != len(model) {
+		t.Logf("keys %v fails\n", keys)
+		t.Fail()
+	}
+	var prev *TrieNode[byte]
+	for i, k := range keys {
+		v, ok := m.Get(k)
+		if !ok || !model[k] || (i > 0 && keys[i-1] >= k) || v.prev != prev {
+			t.Logf("key %d fails\n", k)
+			t.Fail()
+		}
+		if m.Prev(k) != prev || (prev != nil && m.Next(prev.nodeKey) != v) {
+			t.Logf("prev/next %d fails\n", k)
+			t.Fail()
+		}
+		prev = v
+	}
+	if m.Tail() != prev || (prev != nil && m.Head().nodeKey != keys[0]) {
+		t.Log("head/tail fails")
+		t.Fail()
+	}
+}
+
+func TestArtmapClear(t *testing.T) {
+	var m = NewArtMap[byte]().(*ArtMap[byte])
+	for _, n := range []int{3, 10, 40, 200} {
+		for i := 0; i < n; i++ {
+			k := byte(i)
+			m.Set(k, newTrieNodeLeaf(k, []byte{k}, k))
+		}
+		m.Clear()
+		if len(m.nodes) != 4 || len(m.keys) != 4 || m.index != nil || m.size != 0 || m.Head() != nil {
+			t.Logf("clear after %d: %d slots fails\n", n, len(m.nodes))
+			t.Fail()
+		}
+	}
+}
+
+func TestAlphabetMap(t *testing.T) {
+	var alphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	var m = NewAlphabetMap[byte](alphabet)()
+	var keys = []byte(alphabet)
+	for _, i := range rand.Perm(len(keys)) {
+		m.Set(keys[i], newTrieNodeLeaf(keys[i], []byte{keys[i]}, keys[i]))
+	}
+	if ok, i := SliceEq(keys, m.Keys()); !ok {
+		t.Logf("key %d:%s\n%s fails\n", i, keys, m.Keys())
+		t.Fail()
+	}
+	if m.Pad() != '0' || m.Accept('I') || m.Accept('a') || !m.Accept('Z') {
+		t.Log("pad/accept fails")
+		t.Fail()
+	}
+	// a symbol order unlike byte order
+	m = NewAlphabetMap[byte]("zA9")()
+	for _, k := range []byte("9zA") {
+		m.Set(k, newTrieNodeLeaf(k, []byte{k}, k))
+	}
+	if string(m.Keys()) != "zA9" || m.Head().nodeKey != 'z' || m.Tail().nodeKey != '9' ||
+		m.Next('z').nodeKey != 'A' || m.Prev('9').nodeKey != 'A' || m.Head().next.next.nodeKey != '9' {
+		t.Logf("order %s fails\n", m.Keys())
+		t.Fail()
+	}
+	m.Del('A')
+	if string(m.Keys()) != "z9" || m.Head().next.nodeKey != '9' || m.Tail().prev.nodeKey != 'z' {
+		t.Logf("del %s fails\n", m.Keys())
+		t.Fail()
+	}
+}
+
+func TestFoldHexmap(t *testing.T) {
+	var m = NewFoldHexMap[byte]()
+	for _, k := range []byte("0a9F") {
+		m.Set(k, newTrieNodeLeaf(k, []byte{k}, k))
+	}
+	if string(m.Keys()) != "09af" {
+		t.Logf("keys %s fails\n", m.Keys())
+		t.Fail()
+	}
+	for _, k := range []byte("AaFf") {
+		v, ok := m.Get(k)
+		if !ok || v.nodeKey != k && v.nodeKey != k^0x20 {
+			t.Logf("get %c fails\n", k)
+			t.Fail()
+		}
+	}
+	if !m.Accept('B') || m.Accept('G') || NewHexMap[byte]().Accept('B') {
+		t.Log("accept fails")
+		t.Fail()
+	}
+}
+
+func TestFoldAlphabetMap(t *testing.T) {
+	var m = NewFoldAlphabetMap[byte]("0123456789ABCDEFGHJKMNPQRSTVWXYZ")()
+	for _, k := range []byte("z0Ka") {
+		m.Set(k, newTrieNodeLeaf(k, []byte{k}, k))
+	}
+	if string(m.Keys()) != "0AKZ" {
+		t.Logf("keys %s fails\n", m.Keys())
+		t.Fail()
+	}
+	if v, ok := m.Get('Z'); !ok || v.val != 'z' {
+		t.Log("get Z fails")
+		t.Fail()
+	}
+	if m.Accept('i') || !m.Accept('k') {
+		t.Log("accept fails")
+		t.Fail()
+	}
+	defer func() {
+		if recover() == nil {
+			t.Log("both cases should panic")
+			t.Fail()
+		}
+	}()
+	NewFoldAlphabetMap[byte]("aA")
+}
+
+func TestNibblemap(t *testing.T) {
+	var m = NewNibbleMap[byte]()
+	var keys = make([]byte, 0, 16)
+	for i := 0; i < 16; i++ {
+		keys = append(keys, byte(i))
+	}
+	for _, i := range rand.Perm(16) {
+		m.Set(keys[i], newTrieNodeLeaf(keys[i], []byte{keys[i]}, keys[i]))
+	}
+	for _, k := range keys {
+		v, ok := m.Get(k)
+		if !ok || v.val != k {
+			t.Logf("no key:%v fail\n", k)
+			t.Fail()
+		}
+	}
+	if ok, i := SliceEq(keys, m.Keys()); !ok {
+		t.Logf("key %d:%v\n%v fails\n", i, keys, m.Keys())
+		t.Fail()
+	}
+	if m.Accept(16) || m.Head().val != 0 || m.Tail().val != 15 || m.Next(7).val != 8 {
+		t.Log("order fails")
+		t.Fail()
+	}
+}
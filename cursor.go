@@ -0,0 +1,102 @@
+package trie
+
+// Cursor walks the entries of a Trie in key order, in either direction.
+// If the entry under the cursor is deleted, Next and Prev seek again from
+// its key.
+type Cursor[V any] struct {
+	t    *Trie[V]
+	node *TrieNode[V]
+	key  []byte
+	// trie version when node was looked up
+	version uint64
+}
+
+func (t *Trie[V]) Cursor() *Cursor[V] {
+	return &Cursor[V]{t: t}
+}
+
+func (c *Cursor[V]) seek(node *TrieNode[V]) bool {
+	c.node = node
+	if node == nil {
+		c.key = nil
+		return false
+	}
+	c.key = node.key
+	c.version = c.t.version
+	return true
+}
+
+// SeekGE moves to the first entry with key >= key.
+func (c *Cursor[V]) SeekGE(key []byte) bool {
+	return c.seek(c.t.gt(key, true))
+}
+
+// SeekLE moves to the last entry with key <= key.
+func (c *Cursor[V]) SeekLE(key []byte) bool {
+	return c.seek(c.t.lt(key, true))
+}
+
+func (c *Cursor[V]) First() bool {
+	return c.seek(c.t.head)
+}
+
+func (c *Cursor[V]) Last() bool {
+	return c.seek(c.t.tail)
+}
+
+// entry returns the node of the entry under the cursor, or nil once it is
+// deleted. After any change to the trie the entry is looked up again by
+// key: deletes cut whole subtrees without touching their nodes, and path
+// compression moves entries between nodes when it splits or merges edges.
+func (c *Cursor[V]) entry() *TrieNode[V] {
+	if c.node == nil {
+		return nil
+	}
+	if c.version == c.t.version {
+		return c.node
+	}
+	node := c.t.find(c.key)
+	if node == nil {
+		return nil
+	}
+	c.node = node
+	c.version = c.t.version
+	return c.node
+}
+
+func (c *Cursor[V]) Next() bool {
+	if c.node == nil {
+		return false
+	}
+	node := c.entry()
+	if node == nil {
+		return c.seek(c.t.gt(c.key, false))
+	}
+	return c.seek(node.nextLeaf)
+}
+
+func (c *Cursor[V]) Prev() bool {
+	if c.node == nil {
+		return false
+	}
+	node := c.entry()
+	if node == nil {
+		return c.seek(c.t.lt(c.key, false))
+	}
+	return c.seek(node.prevLeaf)
+}
+
+func (c *Cursor[V]) Valid() bool {
+	return c.entry() != nil
+}
+
+func (c *Cursor[V]) Key() []byte {
+	return c.key
+}
+
+func (c *Cursor[V]) Value() (val V) {
+	if node := c.entry(); node != nil {
+		return node.val
+	}
+	return val
+}
@@ -0,0 +1,74 @@
+package trie
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCursor(t *testing.T) {
+	var trie = NewTrie(3, NewNmap[string])
+	for i := 101; i < 200; i += 10 {
+		key := []byte(fmt.Sprint(i))
+		trie.Set(key, string(key))
+	}
+	var c = trie.Cursor()
+	if c.Valid() || c.Next() || c.Prev() {
+		t.Log("unpositioned cursor should be invalid")
+		t.Fail()
+	}
+
+	var vals []string
+	for ok := c.First(); ok; ok = c.Next() {
+		vals = append(vals, c.Value())
+	}
+	if fmt.Sprint(vals) != "[101 111 121 131 141 151 161 171 181 191]" {
+		t.Logf("next: %v fails\n", vals)
+		t.Fail()
+	}
+	vals = vals[:0]
+	for ok := c.Last(); ok; ok = c.Prev() {
+		vals = append(vals, string(c.Key()))
+	}
+	if fmt.Sprint(vals) != "[191 181 171 161 151 141 131 121 111 101]" {
+		t.Logf("prev: %v fails\n", vals)
+		t.Fail()
+	}
+
+	if !c.SeekGE([]byte("125")) || c.Value() != "131" {
+		t.Logf("seek ge 125: %s fails\n", c.Value())
+		t.Fail()
+	}
+	if !c.SeekGE([]byte("131")) || c.Value() != "131" {
+		t.Logf("seek ge 131: %s fails\n", c.Value())
+		t.Fail()
+	}
+	if !c.SeekLE([]byte("125")) || c.Value() != "121" {
+		t.Logf("seek le 125: %s fails\n", c.Value())
+		t.Fail()
+	}
+	if c.SeekGE([]byte("192")) || c.Valid() {
+		t.Log("seek ge 192 should be invalid")
+		t.Fail()
+	}
+	if c.SeekLE([]byte("100")) || c.Valid() {
+		t.Log("seek le 100 should be invalid")
+		t.Fail()
+	}
+	// an empty key is padded to the smallest key
+	if !c.SeekGE(nil) || c.Value() != "101" || c.SeekLE(nil) {
+		t.Logf("seek empty key: %s fails\n", c.Value())
+		t.Fail()
+	}
+
+	c.SeekGE([]byte("141"))
+	trie.Del([]byte("141"))
+	if c.Valid() || !c.Next() || c.Value() != "151" {
+		t.Logf("next after del: %s fails\n", c.Value())
+		t.Fail()
+	}
+	trie.Del([]byte("151"))
+	if !c.Prev() || c.Value() != "131" {
+		t.Logf("prev after del: %s fails\n", c.Value())
+		t.Fail()
+	}
+}
@@ -0,0 +1,97 @@
+package trie
+
+// DeleteRange deletes every entry Scan(l, r) would return and returns how
+// many were removed.
+func (t *Trie[V]) DeleteRange(l, r Key) int {
+	lnode, rnode := t.bounds(l, r)
+	if lnode == nil {
+		return 0
+	}
+	return t.deleteLeaves(lnode, rnode)
+}
+
+// deleteLeaves deletes the entries from first to last, splicing the leaf
+// list once and cutting whole subtrees out of their parent containers.
+func (t *Trie[V]) deleteLeaves(first, last *TrieNode[V]) int {
+	prev, next := first.prevLeaf, last.nextLeaf
+	if prev != nil {
+		prev.nextLeaf = next
+	} else {
+		t.head = next
+	}
+	if next != nil {
+		next.prevLeaf = prev
+	} else {
+		t.tail = prev
+	}
+	n := t.cut(t.root, 0, t.pathKey(first.key), t.pathKey(last.key))
+	t.size -= n
+	t.version++
+	return n
+}
+
+// cut removes the entries between lo and hi from the subtree of node at
+// depth. lo and hi are keys of entries in the trie, nil once the bound no
+// longer constrains the subtree.
+func (t *Trie[V]) cut(node *TrieNode[V], depth int, lo, hi []byte) int {
+	var n int
+	if node.hasVal && (lo == nil || len(lo) == depth) {
+		var zero V
+		if !t.compress {
+			node.key = nil
+		}
+		node.val = zero
+		node.hasVal = false
+		node.version = 0
+		node.prevLeaf = nil
+		node.nextLeaf = nil
+		n++
+	}
+	if lo != nil && len(lo) == depth {
+		lo = nil
+	}
+	if node.children != nil && (hi == nil || len(hi) > depth) {
+		var first, last *TrieNode[V]
+		if lo != nil {
+			first, _ = node.children.Get(lo[depth])
+		} else {
+			first = node.children.Head()
+		}
+		if hi != nil {
+			last, _ = node.children.Get(hi[depth])
+		} else {
+			last = node.children.Tail()
+		}
+		for c := first; c != nil; {
+			next := node.children.Next(c.nodeKey)
+			var clo, chi []byte
+			if c == first {
+				clo = lo
+			}
+			if c == last {
+				chi = hi
+			}
+			if clo == nil && chi == nil {
+				n += c.count
+				c.count = 0
+			} else {
+				n += t.cut(c, depth+1+int(c.edgeLen), clo, chi)
+			}
+			if c.count == 0 {
+				node.children.Del(c.nodeKey)
+			}
+			if c == last {
+				break
+			}
+			c = next
+		}
+	}
+	node.count -= n
+	if node.count > 0 {
+		if node != t.root {
+			t.rekeyNode(node)
+		}
+		t.compact(node)
+	}
+	return n
+}
@@ -0,0 +1,147 @@
+package trie
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"testing"
+)
+
+func testDeleteRange(t *testing.T, newTrie func() *Trie[int], newKey func() []byte) {
+	for round := 0; round < 50; round++ {
+		var trie = newTrie()
+		var model = make(map[string]bool)
+		for i := 0; i < 300; i++ {
+			k := newKey()
+			trie.Set(k, i)
+			model[string(k)] = true
+		}
+		var l, r = Include(newKey()), Exclude(newKey())
+		if round%5 == 0 {
+			l = Unbounded()
+		}
+		var want = len(model)
+		for k := range model {
+			if (l.unbounded || k >= string(l.key)) && k < string(r.key) {
+				delete(model, k)
+			}
+		}
+		want -= len(model)
+		if n := trie.DeleteRange(l, r); n != want {
+			t.Logf("delete range %x-%x: %d-%d fails\n", l.key, r.key, n, want)
+			t.Fail()
+		}
+		if trie.Len() != len(model) || trie.root.count != len(model) {
+			t.Logf("size %d-%d fails\n", trie.Len(), len(model))
+			t.Fail()
+		}
+		var i int
+		var prev []byte
+		for k := range trie.All() {
+			if !model[string(k)] || (prev != nil && bytes.Compare(prev, k) >= 0) {
+				t.Logf("entry %x fails\n", k)
+				t.Fail()
+			}
+			if trie.Rank(k) != i {
+				t.Logf("rank %x fails\n", k)
+				t.Fail()
+			}
+			prev = k
+			i++
+		}
+		var j int
+		for range trie.Backward() {
+			j++
+		}
+		if i != len(model) || j != len(model) {
+			t.Logf("walk %d-%d-%d fails\n", i, j, len(model))
+			t.Fail()
+		}
+		trie.DeleteRange(Unbounded(), Unbounded())
+		if trie.Len() != 0 || trie.head != nil || trie.tail != nil || trie.root.headChild() != nil {
+			t.Log("trie should be empty")
+			t.Fail()
+		}
+	}
+}
+
+func TestDeleteRange(t *testing.T) {
+	testDeleteRange(t, func() *Trie[int] {
+		return NewTrie(3, NewHexMap[int])
+	}, func() []byte {
+		return []byte{toChar(rand.Intn(4)), toChar(rand.Intn(16)), toChar(rand.Intn(16))}
+	})
+	testDeleteRange(t, func() *Trie[int] {
+		return NewVarTrie(NewLinkmap[int])
+	}, func() []byte {
+		k := make([]byte, rand.Intn(5))
+		for i := range k {
+			k[i] = byte(rand.Intn(3))
+		}
+		return k
+	})
+}
+
+func TestDeletePrefix(t *testing.T) {
+	var trie = NewVarTrie(NewByteMap[string])
+	for _, k := range []string{"a", "ab", "abc", "abd", "ac", "b", "ba"} {
+		trie.Set([]byte(k), k)
+	}
+	if n := trie.DeletePrefix([]byte("ab")); n != 3 {
+		t.Logf("delete prefix ab: %d fails\n", n)
+		t.Fail()
+	}
+	if n := trie.DeletePrefix([]byte("ab")); n != 0 {
+		t.Logf("delete prefix ab twice: %d fails\n", n)
+		t.Fail()
+	}
+	if vals := trie.Scan(Unbounded(), Unbounded()); fmt.Sprint(vals) != "[a ac b ba]" || trie.Len() != 4 {
+		t.Logf("scan: %v fails\n", vals)
+		t.Fail()
+	}
+	if _, ok := trie.root.children.Get('a'); !ok || trie.prefixNode([]byte("ab")) != nil {
+		t.Log("prefix ab should be pruned")
+		t.Fail()
+	}
+	if n := trie.DeletePrefix(nil); n != 4 || trie.Len() != 0 || trie.head != nil {
+		t.Logf("delete prefix nil: %d fails\n", n)
+		t.Fail()
+	}
+}
+
+func TestDeleteCursor(t *testing.T) {
+	var newTrie = func() *Trie[string] {
+		var trie = NewVarTrie(NewByteMap[string])
+		for _, k := range []string{"a", "ab", "abc", "abd", "ac", "b"} {
+			trie.Set([]byte(k), k)
+		}
+		return trie
+	}
+	var trie = newTrie()
+	var c = trie.Cursor()
+	c.SeekGE([]byte("abc"))
+	trie.DeletePrefix([]byte("a"))
+	if c.Valid() || c.Value() != "" {
+		t.Logf("cursor after delete prefix: %s fails\n", c.Value())
+		t.Fail()
+	}
+	if !c.Next() || c.Value() != "b" || c.Next() {
+		t.Logf("next after delete prefix: %s fails\n", c.Value())
+		t.Fail()
+	}
+
+	trie = newTrie()
+	c = trie.Cursor()
+	c.SeekGE([]byte("abd"))
+	trie.DeleteRange(Include([]byte("ab")), Exclude([]byte("b")))
+	if c.Valid() || !c.Prev() || c.Value() != "a" {
+		t.Logf("prev after delete range: %s fails\n", c.Value())
+		t.Fail()
+	}
+	c.SeekGE([]byte("b"))
+	trie.DeleteRange(Unbounded(), Unbounded())
+	if c.Valid() || c.Next() || c.Prev() {
+		t.Log("cursor after delete all should be invalid")
+		t.Fail()
+	}
+}
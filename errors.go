@@ -0,0 +1,26 @@
+package trie
+
+import (
+	"strconv"
+)
+
+// ErrKeySize is returned when a key does not have the trie's fixed size.
+type ErrKeySize struct {
+	Size int
+	Want int
+}
+
+func (e ErrKeySize) Error() string {
+	return "trie: key size " + strconv.Itoa(e.Size) + ", should be " + strconv.Itoa(e.Want)
+}
+
+// ErrInvalidSymbol is returned when the key byte at Pos is not accepted by
+// the trie's container.
+type ErrInvalidSymbol struct {
+	Pos  int
+	Byte byte
+}
+
+func (e ErrInvalidSymbol) Error() string {
+	return "trie: invalid symbol " + strconv.Quote(string([]byte{e.Byte})) + " at " + strconv.Itoa(e.Pos)
+}
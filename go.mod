@@ -1,6 +1,6 @@
 module gotrie
 
-go 1.17
+go 1.23
 
 require (
 	github.com/huandu/skiplist v1.2.0
@@ -1,17 +1,20 @@
 package trie
 
-type HexMap struct {
-	buckets [16]*TrieNode
+type HexMap[V any] struct {
+	buckets [16]*TrieNode[V]
 }
 
-func NewHexMap() Container {
-	return &HexMap{}
+func NewHexMap[V any]() Container[V] {
+	return &HexMap[V]{}
 }
 
 func toIndex(k uint8) uint8 {
 	if k >= 'a' {
 		return k - 'a' + 10
 	}
+	if k >= 'A' {
+		return k - 'A' + 10
+	}
 	return k - '0'
 }
 func toChar(k int) uint8 {
@@ -20,7 +23,7 @@ func toChar(k int) uint8 {
 	}
 	return uint8(k) + '0'
 }
-func (m *HexMap) Set(k uint8, v *TrieNode) {
+func (m *HexMap[V]) Set(k uint8, v *TrieNode[V]) {
 	k = toIndex(k)
 	for i := k - 1; i < 16; i-- {
 		if m.buckets[i] != nil {
@@ -38,7 +41,7 @@ func (m *HexMap) Set(k uint8, v *TrieNode) {
 	}
 	m.buckets[k] = v
 }
-func (m *HexMap) Get(k uint8) (*TrieNode, bool) {
+func (m *HexMap[V]) Get(k uint8) (*TrieNode[V], bool) {
 	if m == nil {
 		return nil, false
 	}
@@ -47,7 +50,7 @@ func (m *HexMap) Get(k uint8) (*TrieNode, bool) {
 	return v, v != nil
 }
 
-func (m *HexMap) Del(k byte) bool {
+func (m *HexMap[V]) Del(k byte) bool {
 	if m == nil {
 		return false
 	}
@@ -72,7 +75,7 @@ func (m *HexMap) Del(k byte) bool {
 	return false
 }
 
-func (m *HexMap) Prev(k uint8) *TrieNode {
+func (m *HexMap[V]) Prev(k uint8) *TrieNode[V] {
 	if m == nil {
 		return nil
 	}
@@ -84,19 +87,19 @@ func (m *HexMap) Prev(k uint8) *TrieNode {
 	}
 	return nil
 }
-func (m *HexMap) Next(k uint8) *TrieNode {
+func (m *HexMap[V]) Next(k uint8) *TrieNode[V] {
 	if m == nil {
 		return nil
 	}
 	k = toIndex(k)
-	for i := k + 1; i > k; i++ {
+	for i := k + 1; i < 16; i++ {
 		if m.buckets[i] != nil {
 			return m.buckets[i]
 		}
 	}
 	return nil
 }
-func (m *HexMap) Head() *TrieNode {
+func (m *HexMap[V]) Head() *TrieNode[V] {
 	if m == nil {
 		return nil
 	}
@@ -107,7 +110,7 @@ func (m *HexMap) Head() *TrieNode {
 	}
 	return nil
 }
-func (m *HexMap) Tail() *TrieNode {
+func (m *HexMap[V]) Tail() *TrieNode[V] {
 	if m == nil {
 		return nil
 	}
@@ -118,7 +121,7 @@ func (m *HexMap) Tail() *TrieNode {
 	}
 	return nil
 }
-func (m *HexMap) Keys() []uint8 {
+func (m *HexMap[V]) Keys() []uint8 {
 	if m == nil {
 		return nil
 	}
@@ -131,6 +134,35 @@ func (m *HexMap) Keys() []uint8 {
 	return keys
 }
 
-func (m *HexMap) Pad() byte {
+func (m *HexMap[V]) Pad() byte {
 	return '0'
 }
+
+func (m *HexMap[V]) Accept(k byte) bool {
+	return k >= '0' && k <= '9' || k >= 'a' && k <= 'f'
+}
+
+func (m *HexMap[V]) Clear() {
+	m.buckets = [16]*TrieNode[V]{}
+}
+
+// FoldHexMap is a HexMap that also accepts 'A'-'F', sharing the buckets of
+// their lowercase forms.
+type FoldHexMap[V any] struct {
+	HexMap[V]
+}
+
+func NewFoldHexMap[V any]() Container[V] {
+	return &FoldHexMap[V]{}
+}
+
+func (m *FoldHexMap[V]) Accept(k byte) bool {
+	return m.HexMap.Accept(k) || k >= 'A' && k <= 'F'
+}
+
+func (m *FoldHexMap[V]) Fold(k byte) byte {
+	if k >= 'A' && k <= 'F' {
+		return k - 'A' + 'a'
+	}
+	return k
+}
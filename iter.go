@@ -0,0 +1,43 @@
+package trie
+
+import (
+	"iter"
+)
+
+// All returns an iterator over all entries in ascending key order.
+func (t *Trie[V]) All() iter.Seq2[[]byte, V] {
+	return func(yield func([]byte, V) bool) {
+		for cur := t.head; cur != nil; cur = cur.nextLeaf {
+			if !yield(cur.key, cur.val) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns an iterator over all entries in descending key order.
+func (t *Trie[V]) Backward() iter.Seq2[[]byte, V] {
+	return func(yield func([]byte, V) bool) {
+		for cur := t.tail; cur != nil; cur = cur.prevLeaf {
+			if !yield(cur.key, cur.val) {
+				return
+			}
+		}
+	}
+}
+
+// Range returns an iterator over the entries between lo and hi in ascending
+// key order. The bounds are looked up when the iteration starts.
+func (t *Trie[V]) Range(lo, hi Key) iter.Seq2[[]byte, V] {
+	return func(yield func([]byte, V) bool) {
+		lnode, rnode := t.bounds(lo, hi)
+		if lnode == nil {
+			return
+		}
+		for cur := lnode; cur != nil; cur = cur.nextLeaf {
+			if !yield(cur.key, cur.val) || cur == rnode {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package trie
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestIter(t *testing.T) {
+	var trie = NewTrie(3, NewNmap[string])
+	for i := 101; i < 200; i += 10 {
+		key := []byte(fmt.Sprint(i))
+		trie.Set(key, string(key))
+	}
+	var vals []string
+	for k, v := range trie.All() {
+		if string(k) != v {
+			t.Logf("all %s-%s fails\n", k, v)
+			t.Fail()
+		}
+		vals = append(vals, v)
+	}
+	if fmt.Sprint(vals) != "[101 111 121 131 141 151 161 171 181 191]" {
+		t.Logf("all: %v fails\n", vals)
+		t.Fail()
+	}
+
+	vals = vals[:0]
+	for _, v := range trie.Backward() {
+		if v < "150" {
+			break
+		}
+		vals = append(vals, v)
+	}
+	if fmt.Sprint(vals) != "[191 181 171 161 151]" {
+		t.Logf("backward: %v fails\n", vals)
+		t.Fail()
+	}
+
+	var cases = []struct {
+		lo, hi Key
+		want   string
+	}{
+		{Include([]byte("121")), Exclude([]byte("161")), "[121 131 141 151]"},
+		{Exclude([]byte("121")), Include([]byte("161")), "[131 141 151 161]"},
+		{Include([]byte("000")), Include([]byte("999")), "[101 111 121 131 141 151 161 171 181 191]"},
+		{Exclude([]byte("121")), Exclude([]byte("131")), "[]"},
+		{Include([]byte("161")), Include([]byte("121")), "[]"},
+		{Include([]byte("192")), Include([]byte("999")), "[]"},
+		{Include(nil), Include([]byte("121")), "[101 111 121]"},
+		{Include([]byte("121")), Exclude([]byte{}), "[]"},
+	}
+	for _, c := range cases {
+		vals = vals[:0]
+		for _, v := range trie.Range(c.lo, c.hi) {
+			vals = append(vals, v)
+		}
+		if fmt.Sprint(vals) != c.want {
+			t.Logf("range %s-%s: %v fails\n", c.lo.key, c.hi.key, vals)
+			t.Fail()
+		}
+	}
+
+	vals = vals[:0]
+	for _, v := range trie.Range(Include([]byte("121")), Include([]byte("191"))) {
+		vals = append(vals, v)
+		if len(vals) == 2 {
+			break
+		}
+	}
+	if fmt.Sprint(vals) != "[121 131]" {
+		t.Logf("range break: %v fails\n", vals)
+		t.Fail()
+	}
+}
@@ -1,18 +1,18 @@
 package trie
 
-type LinkMap struct {
-	buckets map[byte]*TrieNode
-	head    *TrieNode
-	tail    *TrieNode
+type LinkMap[V any] struct {
+	buckets map[byte]*TrieNode[V]
+	head    *TrieNode[V]
+	tail    *TrieNode[V]
 }
 
-func NewLinkmap() Container {
-	return &LinkMap{
-		buckets: make(map[byte]*TrieNode, 2),
+func NewLinkmap[V any]() Container[V] {
+	return &LinkMap[V]{
+		buckets: make(map[byte]*TrieNode[V], 2),
 	}
 }
 
-func (m *LinkMap) Set(k byte, v *TrieNode) {
+func (m *LinkMap[V]) Set(k byte, v *TrieNode[V]) {
 	_, ok := m.buckets[k]
 	if ok {
 		return
@@ -66,7 +66,7 @@ func (m *LinkMap) Set(k byte, v *TrieNode) {
 	panic(k)
 }
 
-func (m *LinkMap) Get(k byte) (*TrieNode, bool) {
+func (m *LinkMap[V]) Get(k byte) (*TrieNode[V], bool) {
 	if m == nil {
 		return nil, false
 	}
@@ -74,7 +74,7 @@ func (m *LinkMap) Get(k byte) (*TrieNode, bool) {
 	return v, ok
 }
 
-func (m *LinkMap) Del(k byte) bool {
+func (m *LinkMap[V]) Del(k byte) bool {
 	if m == nil {
 		return false
 	}
@@ -92,13 +92,19 @@ func (m *LinkMap) Del(k byte) bool {
 		if next != nil {
 			next.prev = prev
 		}
+		if m.head == v {
+			m.head = next
+		}
+		if m.tail == v {
+			m.tail = prev
+		}
 		delete(m.buckets, k)
 		return true
 	}
 	return false
 }
 
-func (m *LinkMap) Prev(k uint8) *TrieNode {
+func (m *LinkMap[V]) Prev(k uint8) *TrieNode[V] {
 	if m == nil {
 		return nil
 	}
@@ -106,9 +112,14 @@ func (m *LinkMap) Prev(k uint8) *TrieNode {
 	if ok {
 		return v.prev
 	}
+	for cur := m.tail; cur != nil; cur = cur.prev {
+		if cur.nodeKey < k {
+			return cur
+		}
+	}
 	return nil
 }
-func (m *LinkMap) Next(k byte) *TrieNode {
+func (m *LinkMap[V]) Next(k byte) *TrieNode[V] {
 	if m == nil {
 		return nil
 	}
@@ -116,9 +127,14 @@ func (m *LinkMap) Next(k byte) *TrieNode {
 	if ok {
 		return v.next
 	}
+	for cur := m.head; cur != nil; cur = cur.next {
+		if cur.nodeKey > k {
+			return cur
+		}
+	}
 	return nil
 }
-func (m *LinkMap) Head() *TrieNode {
+func (m *LinkMap[V]) Head() *TrieNode[V] {
 	if m == nil {
 		return nil
 	}
@@ -127,7 +143,7 @@ func (m *LinkMap) Head() *TrieNode {
 	}
 	return m.head
 }
-func (m *LinkMap) Tail() *TrieNode {
+func (m *LinkMap[V]) Tail() *TrieNode[V] {
 	if m == nil {
 		return nil
 	}
@@ -136,7 +152,7 @@ func (m *LinkMap) Tail() *TrieNode {
 	}
 	return m.tail
 }
-func (m *LinkMap) Keys() []byte {
+func (m *LinkMap[V]) Keys() []byte {
 	if m == nil {
 		return nil
 	}
@@ -147,6 +163,16 @@ func (m *LinkMap) Keys() []byte {
 	return keys
 }
 
-func (m *LinkMap) Pad() byte {
+func (m *LinkMap[V]) Pad() byte {
 	return 0
 }
+
+func (m *LinkMap[V]) Accept(k byte) bool {
+	return true
+}
+
+func (m *LinkMap[V]) Clear() {
+	clear(m.buckets)
+	m.head = nil
+	m.tail = nil
+}
@@ -0,0 +1,153 @@
+package trie
+
+// NibbleMap is a 16-way container over the nibbles 0-15, used by the
+// tries of NewNibbleTrie.
+type NibbleMap[V any] struct {
+	buckets [16]*TrieNode[V]
+}
+
+func NewNibbleMap[V any]() Container[V] {
+	return &NibbleMap[V]{}
+}
+
+// NewNibbleTrie returns a Trie of raw binary keys that walks every key by
+// its nibbles, high one first, so each node has at most 16 children. Keys
+// keep their byte order and are returned as they were set.
+func NewNibbleTrie[V any](keySize int, opts ...Option) *Trie[V] {
+	t := NewTrie(keySize, NewNibbleMap[V], opts...)
+	t.nibbles = true
+	return t
+}
+
+// toNibbles splits every byte of key into its high and low nibble.
+func toNibbles(key []byte) []byte {
+	var sym = make([]byte, 2*len(key))
+	for i, k := range key {
+		sym[2*i] = k >> 4
+		sym[2*i+1] = k & 0xf
+	}
+	return sym
+}
+
+// nibble returns the i-th nibble of key.
+func nibble(key []byte, i int) byte {
+	if i%2 == 0 {
+		return key[i/2] >> 4
+	}
+	return key[i/2] & 0xf
+}
+
+func (m *NibbleMap[V]) Set(k byte, v *TrieNode[V]) {
+	for i := int(k) - 1; i >= 0; i-- {
+		if m.buckets[i] != nil {
+			m.buckets[i].next = v
+			v.prev = m.buckets[i]
+			break
+		}
+	}
+	for i := int(k) + 1; i < 16; i++ {
+		if m.buckets[i] != nil {
+			m.buckets[i].prev = v
+			v.next = m.buckets[i]
+			break
+		}
+	}
+	m.buckets[k] = v
+}
+
+func (m *NibbleMap[V]) Get(k byte) (*TrieNode[V], bool) {
+	if m == nil || k > 0xf {
+		return nil, false
+	}
+	v := m.buckets[k]
+	return v, v != nil
+}
+
+func (m *NibbleMap[V]) Del(k byte) bool {
+	v, ok := m.Get(k)
+	if !ok {
+		return false
+	}
+	if v.prev != nil {
+		v.prev.next = v.next
+	}
+	if v.next != nil {
+		v.next.prev = v.prev
+	}
+	m.buckets[k] = nil
+	v.Free()
+	return true
+}
+
+func (m *NibbleMap[V]) Prev(k byte) *TrieNode[V] {
+	if m == nil {
+		return nil
+	}
+	for i := int(k) - 1; i >= 0; i-- {
+		if m.buckets[i] != nil {
+			return m.buckets[i]
+		}
+	}
+	return nil
+}
+
+func (m *NibbleMap[V]) Next(k byte) *TrieNode[V] {
+	if m == nil {
+		return nil
+	}
+	for i := int(k) + 1; i < 16; i++ {
+		if m.buckets[i] != nil {
+			return m.buckets[i]
+		}
+	}
+	return nil
+}
+
+func (m *NibbleMap[V]) Head() *TrieNode[V] {
+	if m == nil {
+		return nil
+	}
+	for _, v := range m.buckets {
+		if v != nil {
+			return v
+		}
+	}
+	return nil
+}
+
+func (m *NibbleMap[V]) Tail() *TrieNode[V] {
+	if m == nil {
+		return nil
+	}
+	for i := 15; i >= 0; i-- {
+		if v := m.buckets[i]; v != nil {
+			return v
+		}
+	}
+	return nil
+}
+
+func (m *NibbleMap[V]) Keys() []byte {
+	if m == nil {
+		return nil
+	}
+	var keys = make([]byte, 0, 16)
+	for i, v := range m.buckets {
+		if v != nil {
+			keys = append(keys, byte(i))
+		}
+	}
+	return keys
+}
+
+func (m *NibbleMap[V]) Pad() byte {
+	return 0
+}
+
+func (m *NibbleMap[V]) Accept(k byte) bool {
+	return k <= 0xf
+}
+
+func (m *NibbleMap[V]) Clear() {
+	m.buckets = [16]*TrieNode[V]{}
+}
@@ -1,14 +1,14 @@
 package trie
 
-type Nmap struct {
-	buckets [10]*TrieNode
+type Nmap[V any] struct {
+	buckets [10]*TrieNode[V]
 }
 
-func NewNmap() Container {
-	return &Nmap{}
+func NewNmap[V any]() Container[V] {
+	return &Nmap[V]{}
 }
 
-func (m *Nmap) Set(k byte, v *TrieNode) {
+func (m *Nmap[V]) Set(k byte, v *TrieNode[V]) {
 	k = k - '0'
 	for i := k - 1; i < 10; i-- {
 		if m.buckets[i] != nil {
@@ -26,12 +26,12 @@ func (m *Nmap) Set(k byte, v *TrieNode) {
 	}
 	m.buckets[k] = v
 }
-func (m *Nmap) Get(k byte) (*TrieNode, bool) {
+func (m *Nmap[V]) Get(k byte) (*TrieNode[V], bool) {
 	v := m.buckets[k-'0']
 	return v, v != nil
 }
 
-func (m *Nmap) Del(k byte) bool {
+func (m *Nmap[V]) Del(k byte) bool {
 	k = k - '0'
 	v := m.buckets[k]
 	defer func() {
@@ -53,7 +53,7 @@ func (m *Nmap) Del(k byte) bool {
 	return false
 }
 
-func (m *Nmap) Prev(k byte) *TrieNode {
+func (m *Nmap[V]) Prev(k byte) *TrieNode[V] {
 	k = k - '0'
 	for i := k - 1; i < k; i-- {
 		if m.buckets[i] != nil {
@@ -62,7 +62,7 @@ func (m *Nmap) Prev(k byte) *TrieNode {
 	}
 	return nil
 }
-func (m *Nmap) Next(k byte) *TrieNode {
+func (m *Nmap[V]) Next(k byte) *TrieNode[V] {
 	k = k - '0'
 	for i := k + 1; i < 10; i++ {
 		if m.buckets[i] != nil {
@@ -71,7 +71,7 @@ func (m *Nmap) Next(k byte) *TrieNode {
 	}
 	return nil
 }
-func (m *Nmap) Head() *TrieNode {
+func (m *Nmap[V]) Head() *TrieNode[V] {
 	for i := 0; i < 10; i++ {
 		if v := m.buckets[byte(i)]; v != nil {
 			return v
@@ -79,7 +79,7 @@ func (m *Nmap) Head() *TrieNode {
 	}
 	return nil
 }
-func (m *Nmap) Tail() *TrieNode {
+func (m *Nmap[V]) Tail() *TrieNode[V] {
 	for i := 9; i >= 0; i-- {
 		if v := m.buckets[byte(i)]; v != nil {
 			return v
@@ -87,7 +87,7 @@ func (m *Nmap) Tail() *TrieNode {
 	}
 	return nil
 }
-func (m *Nmap) Keys() []byte {
+func (m *Nmap[V]) Keys() []byte {
 	var keys = make([]byte, 0, 10)
 	for i, v := range m.buckets {
 		if v != nil {
@@ -97,6 +97,14 @@ func (m *Nmap) Keys() []byte {
 	return keys
 }
 
-func (m *Nmap) Pad() byte {
+func (m *Nmap[V]) Pad() byte {
 	return '0'
 }
+
+func (m *Nmap[V]) Accept(k byte) bool {
+	return k >= '0' && k <= '9'
+}
+
+func (m *Nmap[V]) Clear() {
+	m.buckets = [10]*TrieNode[V]{}
+}
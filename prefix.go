@@ -0,0 +1,106 @@
+package trie
+
+import (
+	"bytes"
+)
+
+// prefixNode returns the node under which all keys starting with prefix
+// live, or nil if there is none.
+func (t *Trie[V]) prefixNode(prefix []byte) *TrieNode[V] {
+	if t.keySize > 0 && len(prefix) > t.keySize {
+		return nil
+	}
+	if t.checkSymbols(prefix) != nil {
+		return nil
+	}
+	prefix = t.pathKey(prefix)
+	cur := t.root
+	for depth := 0; depth < len(prefix); depth += 1 + int(cur.edgeLen) {
+		if cur.children == nil {
+			return nil
+		}
+		c, ok := cur.children.Get(prefix[depth])
+		if !ok {
+			return nil
+		}
+		// prefix may end inside a compressed edge
+		edge, rest := t.edge(c, depth), prefix[depth+1:]
+		if len(rest) < len(edge) {
+			if !bytes.Equal(edge[:len(rest)], rest) {
+				return nil
+			}
+			return c
+		}
+		if !bytes.Equal(edge, rest[:len(edge)]) {
+			return nil
+		}
+		cur = c
+	}
+	return cur
+}
+
+// prefixBounds returns the first and last entries whose key starts with
+// prefix, or nil nodes when there are none.
+func (t *Trie[V]) prefixBounds(prefix []byte) (first, last *TrieNode[V]) {
+	node := t.prefixNode(prefix)
+	if node == nil {
+		return nil, nil
+	}
+	if first = firstLeaf(node); first == nil {
+		return nil, nil
+	}
+	return first, lastLeaf(node)
+}
+
+func (t *Trie[V]) HasPrefix(prefix []byte) bool {
+	first, _ := t.prefixBounds(prefix)
+	return first != nil
+}
+
+func (t *Trie[V]) FirstWithPrefix(prefix []byte) ([]byte, V, bool) {
+	first, _ := t.prefixBounds(prefix)
+	return first.entry()
+}
+
+func (t *Trie[V]) LastWithPrefix(prefix []byte) ([]byte, V, bool) {
+	_, last := t.prefixBounds(prefix)
+	return last.entry()
+}
+
+func (t *Trie[V]) ForeachPrefix(prefix []byte, f func(key []byte, val V)) {
+	first, last := t.prefixBounds(prefix)
+	if first == nil {
+		return
+	}
+	for cur := first; cur != nil; cur = cur.nextLeaf {
+		f(cur.key, cur.val)
+		if cur == last {
+			return
+		}
+	}
+}
+
+func (t *Trie[V]) ScanPrefix(prefix []byte) []V {
+	var vals []V
+	t.ForeachPrefix(prefix, func(key []byte, val V) {
+		vals = append(vals, val)
+	})
+	return vals
+}
+
+func (t *Trie[V]) CountPrefix(prefix []byte) int {
+	if node := t.prefixNode(prefix); node != nil {
+		return node.count
+	}
+	return 0
+}
+
+// DeletePrefix deletes every entry whose key starts with prefix and returns
+// how many were removed.
+func (t *Trie[V]) DeletePrefix(prefix []byte) int {
+	first, last := t.prefixBounds(prefix)
+	if first == nil {
+		return 0
+	}
+	return t.deleteLeaves(first, last)
+}
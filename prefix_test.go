@@ -0,0 +1,74 @@
+package trie
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestPrefix(t *testing.T) {
+	var trie = NewTrie(4, NewHexMap[string])
+	for i := 0; i < 0x300; i += 3 {
+		key := fmt.Sprintf("%04x", i)
+		trie.Set([]byte(key), key)
+	}
+	var cases = []struct {
+		prefix      string
+		first, last string
+		n           int
+	}{
+		{"", "0000", "02fd", 256},
+		{"01", "0102", "01fe", 85},
+		{"010", "0102", "010e", 5},
+		{"0100", "", "", 0},
+		{"0102", "0102", "0102", 1},
+		{"03", "", "", 0},
+		{"0g", "", "", 0},
+		{"01020", "", "", 0},
+	}
+	for _, c := range cases {
+		p := []byte(c.prefix)
+		if trie.HasPrefix(p) != (c.n > 0) {
+			t.Logf("has prefix %s fails\n", c.prefix)
+			t.Fail()
+		}
+		if key, val, ok := trie.FirstWithPrefix(p); string(key) != c.first || val != c.first || ok != (c.n > 0) {
+			t.Logf("first with prefix %s: %s fails\n", c.prefix, key)
+			t.Fail()
+		}
+		if key, val, ok := trie.LastWithPrefix(p); string(key) != c.last || val != c.last || ok != (c.n > 0) {
+			t.Logf("last with prefix %s: %s fails\n", c.prefix, key)
+			t.Fail()
+		}
+		vals := trie.ScanPrefix(p)
+		if len(vals) != c.n || trie.CountPrefix(p) != c.n {
+			t.Logf("scan prefix %s: %d fails\n", c.prefix, len(vals))
+			t.Fail()
+		}
+		for _, v := range vals {
+			if v[:len(c.prefix)] != c.prefix {
+				t.Logf("scan prefix %s: %s fails\n", c.prefix, v)
+				t.Fail()
+			}
+		}
+	}
+}
+
+func TestVarPrefix(t *testing.T) {
+	var trie = NewVarTrie(NewByteMap[string])
+	for _, k := range []string{"a", "ab", "abc", "abd", "b", "ba"} {
+		trie.Set([]byte(k), k)
+	}
+	if vals := trie.ScanPrefix([]byte("ab")); fmt.Sprint(vals) != "[ab abc abd]" {
+		t.Logf("scan prefix ab: %v fails\n", vals)
+		t.Fail()
+	}
+	trie.Del([]byte("ab"))
+	if vals := trie.ScanPrefix([]byte("ab")); fmt.Sprint(vals) != "[abc abd]" {
+		t.Logf("scan prefix ab: %v fails\n", vals)
+		t.Fail()
+	}
+	if key, _, _ := trie.LastWithPrefix([]byte("a")); string(key) != "abd" {
+		t.Logf("last with prefix a: %s fails\n", key)
+		t.Fail()
+	}
+}
@@ -0,0 +1,134 @@
+package trie
+
+type options struct {
+	compress bool
+	// set by NewNibbleTrie
+	nibbles bool
+}
+
+type Option func(*options)
+
+// WithPathCompression collapses chains of single-child nodes into one node
+// with a compressed edge, as in a radix tree. Edges are split again on
+// demand when a key branches off inside them.
+//
+// A compressed edge is not stored, it is read from the key of the node,
+// which with path compression stays set on nodes without an entry too: any
+// key below a node passes through its edge. Deletes move such a node to a
+// key still in the trie, so keys handed back by Del or PopFirst are the
+// caller's to modify.
+func WithPathCompression() Option {
+	return func(o *options) {
+		o.compress = true
+	}
+}
+
+// edge returns the compressed symbols after the nodeKey of node, where
+// nodeKey sits at depth in the path key.
+func (t *Trie[V]) edge(node *TrieNode[V], depth int) []byte {
+	if node.edgeLen == 0 {
+		return nil
+	}
+	from, to := depth+1, depth+1+int(node.edgeLen)
+	if !t.nibbles {
+		return node.key[from:to]
+	}
+	edge := make([]byte, 0, to-from)
+	for i := from; i < to; i++ {
+		edge = append(edge, nibble(node.key, i))
+	}
+	return edge
+}
+
+// compareEdge orders the subtree below an edge against the rest of a key:
+// 0 when edge is a prefix of rest, 1 when the subtree sorts after the key
+// and -1 when it sorts before.
+func (t *Trie[V]) compareEdge(edge, rest []byte) int {
+	if len(edge) == 0 {
+		return 0
+	}
+	n := min(len(edge), len(rest))
+	if c := t.compare(edge[:n], rest[:n]); c != 0 || n == len(edge) {
+		return c
+	}
+	return 1
+}
+
+// pathDepth returns the length of the key prefix at the end of path.
+func pathDepth[V any](path []*TrieNode[V]) int {
+	var depth int
+	for _, node := range path[1:] {
+		depth += 1 + int(node.edgeLen)
+	}
+	return depth
+}
+
+// split cuts the edge of c, whose nodeKey sits at depth, where it stops
+// matching key. c stays in its parent container as the interior node at
+// the cut, and its old contents move to a new child below it.
+func (t *Trie[V]) split(c *TrieNode[V], depth int, key []byte) *TrieNode[V] {
+	edge, rest := t.edge(c, depth), key[depth+1:]
+	var n int
+	for n < len(edge) && n < len(rest) && edge[n] == rest[n] {
+		n++
+	}
+	// low hands its empty container to c
+	low := t.newNode(edge[n])
+	low.edgeLen = uint32(len(edge) - n - 1)
+	low.key = c.key
+	low.val = c.val
+	low.hasVal = c.hasVal
+	low.version = c.version
+	low.count = c.count
+	low.children, c.children = c.children, low.children
+	if low.hasVal {
+		t.relink(c, low)
+	}
+	var zero V
+	c.edgeLen = uint32(n)
+	c.val = zero
+	c.hasVal = false
+	c.version = 0
+	c.children.Set(low.nodeKey, low)
+	return c
+}
+
+// compact merges node with its only child once node holds no entry, so
+// that single-child chains stay collapsed after deletes.
+func (t *Trie[V]) compact(node *TrieNode[V]) {
+	if !t.compress || node == t.root || node.hasVal {
+		return
+	}
+	c := node.headChild()
+	if c == nil || c != node.children.Tail() {
+		return
+	}
+	node.edgeLen += 1 + c.edgeLen
+	node.key = c.key
+	node.val = c.val
+	node.hasVal = c.hasVal
+	node.version = c.version
+	node.children = c.children
+	if node.hasVal {
+		t.relink(c, node)
+	}
+	c.Free()
+}
+
+// relink puts node in the place of old in the leaf list.
+func (t *Trie[V]) relink(old, node *TrieNode[V]) {
+	node.prevLeaf = old.prevLeaf
+	node.nextLeaf = old.nextLeaf
+	if node.prevLeaf != nil {
+		node.prevLeaf.nextLeaf = node
+	} else {
+		t.head = node
+	}
+	if node.nextLeaf != nil {
+		node.nextLeaf.prevLeaf = node
+	} else {
+		t.tail = node
+	}
+	old.prevLeaf = nil
+	old.nextLeaf = nil
+}
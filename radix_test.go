@@ -0,0 +1,299 @@
+package trie
+
+import (
+	"bytes"
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func countNodes[V any](node *TrieNode[V]) int {
+	n := 1
+	if node.children != nil {
+		for c := node.children.Head(); c != nil; c = node.children.Next(c.nodeKey) {
+			n += countNodes(c)
+		}
+	}
+	return n
+}
+
+// checkCompact reports nodes a compressed trie should have merged away.
+func checkCompact[V any](t *testing.T, trie *Trie[V], node *TrieNode[V]) {
+	var n int
+	if node.children != nil {
+		for c := node.children.Head(); c != nil; c = node.children.Next(c.nodeKey) {
+			checkCompact(t, trie, c)
+			n++
+		}
+	}
+	if node != trie.root && !node.hasVal && n < 2 {
+		t.Logf("node %x with %d children fails\n", node.key, n)
+		t.Fail()
+	}
+}
+
+func testRadix(t *testing.T, newTrie func(opts ...Option) *Trie[int], newKey func() []byte) {
+	var trie, plain = newTrie(WithPathCompression()), newTrie()
+	testSame(t, trie, plain, newKey)
+	checkCompact(t, trie, trie.root)
+	if n, pn := countNodes(trie.root), countNodes(plain.root); n > pn || n > 2*trie.Len()+1 {
+		t.Logf("nodes %d-%d fails\n", n, pn)
+		t.Fail()
+	}
+}
+
+// testSame runs random operations on both tries and compares the results.
+func testSame(t *testing.T, trie, plain *Trie[int], newKey func() []byte) {
+	for round := 0; round < 5000; round++ {
+		k, v := newKey(), rand.Int()
+		switch rand.Intn(4) {
+		case 0:
+			old, ok := trie.Del(k)
+			pold, pok := plain.Del(k)
+			if old != pold || ok != pok {
+				t.Logf("del %x fails\n", k)
+				t.Fail()
+			}
+		default:
+			trie.Set(k, v)
+			plain.Set(k, v)
+		}
+		q := newKey()
+		v1, ok1 := trie.Get(q)
+		v2, ok2 := plain.Get(q)
+		if v1 != v2 || ok1 != ok2 {
+			t.Logf("get %x fails\n", q)
+			t.Fail()
+		}
+		for _, e := range []bool{false, true} {
+			k1, _, _ := trie.gt(q, e).entry()
+			k2, _, _ := plain.gt(q, e).entry()
+			k3, _, _ := trie.lt(q, e).entry()
+			k4, _, _ := plain.lt(q, e).entry()
+			if !bytes.Equal(k1, k2) || !bytes.Equal(k3, k4) {
+				t.Logf("gt/lt %x %v: %x-%x %x-%x fails\n", q, e, k1, k2, k3, k4)
+				t.Fail()
+			}
+		}
+		if trie.Rank(q) != plain.Rank(q) {
+			t.Logf("rank %x fails\n", q)
+			t.Fail()
+		}
+		p := q[:rand.Intn(len(q)+1)]
+		if trie.CountPrefix(p) != plain.CountPrefix(p) || len(trie.ScanPrefix(p)) != trie.CountPrefix(p) {
+			t.Logf("prefix %x fails\n", p)
+			t.Fail()
+		}
+		if round%500 == 0 {
+			l, r := Include(newKey()), Exclude(newKey())
+			if trie.DeleteRange(l, r) != plain.DeleteRange(l, r) {
+				t.Logf("delete range %x-%x fails\n", l.key, r.key)
+				t.Fail()
+			}
+		}
+	}
+	if trie.Len() != plain.Len() || trie.root.count != plain.Len() {
+		t.Logf("size %d-%d fails\n", trie.Len(), plain.Len())
+		t.Fail()
+	}
+	var keys = plain.Keys()
+	var i int
+	for k, v := range trie.All() {
+		if pv, _ := plain.Get(k); !bytes.Equal(k, keys[i]) || v != pv {
+			t.Logf("entry %x fails\n", k)
+			t.Fail()
+		}
+		i++
+	}
+	if i != len(keys) {
+		t.Logf("walk %d-%d fails\n", i, len(keys))
+		t.Fail()
+	}
+}
+
+func TestRadix(t *testing.T) {
+	testRadix(t, func(opts ...Option) *Trie[int] {
+		return NewTrie(4, NewByteMap[int], opts...)
+	}, func() []byte {
+		return []byte{byte(rand.Intn(3)), byte(rand.Intn(2)), byte(rand.Intn(4)), byte(rand.Intn(3))}
+	})
+	testRadix(t, func(opts ...Option) *Trie[int] {
+		return NewVarTrie(NewArtMap[int], opts...)
+	}, func() []byte {
+		k := make([]byte, rand.Intn(7))
+		for i := range k {
+			k[i] = byte(rand.Intn(3))
+		}
+		return k
+	})
+	testDeleteRange(t, func() *Trie[int] {
+		return NewVarTrie(NewByteMap[int], WithPathCompression())
+	}, func() []byte {
+		k := make([]byte, rand.Intn(6))
+		for i := range k {
+			k[i] = byte(rand.Intn(3))
+		}
+		return k
+	})
+	testRadix(t, func(opts ...Option) *Trie[int] {
+		return NewTrie(8, NewHexMap[int], opts...)
+	}, func() []byte {
+		k := []byte("0f0f0f0f")
+		for i := 4 + rand.Intn(4); i < len(k); i++ {
+			k[i] = toChar(rand.Intn(16))
+		}
+		return k
+	})
+}
+
+func TestRadixNodes(t *testing.T) {
+	var trie, plain = NewVarTrie(NewLinkmap[int], WithPathCompression()), NewVarTrie(NewLinkmap[int])
+	for i := 0; i < 1000; i++ {
+		k := make([]byte, 32)
+		rand.Read(k)
+		trie.Set(k, i)
+		plain.Set(k, i)
+	}
+	if n, pn := countNodes(trie.root), countNodes(plain.root); n*10 > pn {
+		t.Logf("nodes %d-%d fails\n", n, pn)
+		t.Fail()
+	}
+	clone := trie.Clone()
+	for k := range trie.All() {
+		if _, ok := clone.Get(k); !ok {
+			t.Logf("clone %x fails\n", k)
+			t.Fail()
+		}
+		clone.Del(k)
+	}
+	if clone.Len() != 0 || clone.root.headChild() != nil || trie.Len() != 1000 {
+		t.Log("clone should be empty")
+		t.Fail()
+	}
+}
+
+func TestNibbleTrie(t *testing.T) {
+	var newKey = func() []byte {
+		var syms = []byte{0x00, 0x0f, 0x10, 0x1f, 0xf0, 0xff}
+		k := make([]byte, 3)
+		for i := range k {
+			k[i] = syms[rand.Intn(len(syms))]
+		}
+		return k
+	}
+	testSame(t, NewNibbleTrie[int](3), NewTrie(3, NewByteMap[int]), newKey)
+	var trie = NewNibbleTrie[int](3, WithPathCompression())
+	testSame(t, trie, NewTrie(3, NewByteMap[int]), newKey)
+	checkCompact(t, trie, trie.root)
+
+	trie = NewNibbleTrie[int](12)
+	var ids = make([][]byte, 0, 100)
+	for i := 0; i < 100; i++ {
+		id := primitive.NewObjectID()
+		ids = append(ids, id[:])
+		trie.Set(id[:], i)
+	}
+	var i int
+	trie.Foreach(func(key []byte, val int) {
+		if !bytes.Equal(key, ids[val]) || trie.Rank(key) != i {
+			t.Logf("key %x fails\n", key)
+			t.Fail()
+		}
+		i++
+	})
+	// one node per nibble at most
+	if n := countNodes(trie.root); n > 1+100*24 {
+		t.Logf("nodes %d fails\n", n)
+		t.Fail()
+	}
+}
+
+func TestRadixCursor(t *testing.T) {
+	var trie = NewVarTrie(NewByteMap[string], WithPathCompression())
+	for _, k := range []string{"abc", "abd", "b"} {
+		trie.Set([]byte(k), k)
+	}
+	var c = trie.Cursor()
+	c.SeekGE([]byte("abd"))
+	// merges abd into the node above it
+	trie.Del([]byte("abc"))
+	if !c.Valid() || c.Value() != "abd" {
+		t.Logf("cursor after compact: %s fails\n", c.Value())
+		t.Fail()
+	}
+	if !c.Next() || c.Value() != "b" || !c.Prev() || c.Value() != "abd" {
+		t.Logf("next after compact: %s fails\n", c.Value())
+		t.Fail()
+	}
+
+	trie.Set([]byte("abcd"), "abcd")
+	c.SeekGE([]byte("abcd"))
+	// splits the edge above abcd, then gives the split node an entry
+	trie.Set([]byte("abx"), "abx")
+	trie.Set([]byte("ab"), "ab")
+	if !c.Valid() || c.Value() != "abcd" {
+		t.Logf("cursor after split: %s fails\n", c.Value())
+		t.Fail()
+	}
+	if !c.Next() || c.Value() != "abd" || !c.Prev() || !c.Prev() || c.Value() != "ab" {
+		t.Logf("next after split: %s fails\n", c.Value())
+		t.Fail()
+	}
+	trie.Del([]byte("ab"))
+	if c.Valid() || !c.Next() || c.Value() != "abcd" {
+		t.Logf("next after del: %s fails\n", c.Value())
+		t.Fail()
+	}
+}
+
+func TestRadixOwnedKeys(t *testing.T) {
+	var newTrie = func() *Trie[string] {
+		var trie = NewVarTrie(NewByteMap[string], WithPathCompression())
+		for _, k := range []string{"abc", "abcd", "abce", "abxy", "abxz"} {
+			trie.Set([]byte(k), k)
+		}
+		return trie
+	}
+	var check = func(name string, trie *Trie[string], want string) {
+		var got []string
+		for k, v := range trie.All() {
+			if w, ok := trie.Get(k); !ok || w != v || string(k) != v {
+				t.Logf("%s: get %s fails\n", name, k)
+				t.Fail()
+			}
+			got = append(got, v)
+		}
+		if fmt.Sprint(got) != want {
+			t.Logf("%s: %v fails\n", name, got)
+			t.Fail()
+		}
+	}
+
+	// abc is an interior entry, its node keeps the edge to abcd and abce
+	var trie = newTrie()
+	k, _, _ := trie.PopFirst()
+	k[1] = 'z'
+	check("pop first", trie, "[abcd abce abxy abxz]")
+
+	// abxy shares its key with the split node above it
+	trie = newTrie()
+	k, _, _ = trie.PopLast()
+	k[1] = 'z'
+	k, _, _ = trie.First()
+	trie.Del(k)
+	copy(k, "zzz")
+	check("del", trie, "[abcd abce abxy]")
+
+	trie = newTrie()
+	k, _, _ = trie.First()
+	trie.DeletePrefix([]byte("abc"))
+	copy(k, "zzz")
+	check("delete prefix", trie, "[abxy abxz]")
+	trie = newTrie()
+	k, _, _ = trie.First()
+	trie.DeleteRange(Include([]byte("abc")), Exclude([]byte("abcd")))
+	copy(k, "zzz")
+	check("delete range", trie, "[abcd abce abxy abxz]")
+}
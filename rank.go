@@ -0,0 +1,76 @@
+package trie
+
+// index returns the number of entries ordered before key, which must be a
+// valid key.
+func (t *Trie[V]) index(key []byte) int {
+	key = t.pathKey(key)
+	var n int
+	cur := t.root
+	for depth := 0; depth < len(key); depth += 1 + int(cur.edgeLen) {
+		k := key[depth]
+		if cur.hasVal {
+			n++
+		}
+		if cur.children == nil {
+			break
+		}
+		for c := cur.children.Prev(k); c != nil; c = cur.children.Prev(c.nodeKey) {
+			n += c.count
+		}
+		next, ok := cur.children.Get(k)
+		if !ok {
+			break
+		}
+		cur = next
+	}
+	return n
+}
+
+// rank returns the number of entries before key, or up to and including
+// key when e is set.
+func (t *Trie[V]) rank(key []byte, e bool) int {
+	if node := t.lt(key, e); node != nil {
+		return t.index(node.key) + 1
+	}
+	return 0
+}
+
+// Rank returns the number of entries with a key less than key, which is
+// the position of key when it is in the trie.
+func (t *Trie[V]) Rank(key []byte) int {
+	return t.rank(key, false)
+}
+
+// Select returns the entry at position i in key order.
+func (t *Trie[V]) Select(i int) ([]byte, V, bool) {
+	if i < 0 || i >= t.size {
+		return (*TrieNode[V])(nil).entry()
+	}
+	cur := t.root
+	for {
+		if cur.hasVal {
+			if i == 0 {
+				return cur.entry()
+			}
+			i--
+		}
+		c := cur.children.Head()
+		for i >= c.count {
+			i -= c.count
+			c = cur.children.Next(c.nodeKey)
+		}
+		cur = c
+	}
+}
+
+// Count returns the number of entries Scan(l, r) would return.
+func (t *Trie[V]) Count(l, r Key) int {
+	lnode, rnode := t.bounds(l, r)
+	if lnode == nil {
+		return 0
+	}
+	if lnode == rnode {
+		return 1
+	}
+	return t.index(rnode.key) - t.index(lnode.key) + 1
+}
@@ -0,0 +1,116 @@
+package trie
+
+import (
+	"bytes"
+	"math/rand"
+	"sort"
+	"strconv"
+	"testing"
+)
+
+func testRank(t *testing.T, trie *Trie[int], keys [][]byte) {
+	sort.Slice(keys, func(i, j int) bool {
+		return bytes.Compare(keys[i], keys[j]) < 0
+	})
+	for i, k := range keys {
+		if r := trie.Rank(k); r != i {
+			t.Logf("rank %x: %d-%d fails\n", k, r, i)
+			t.Fail()
+		}
+		if key, _, ok := trie.Select(i); !ok || !bytes.Equal(key, k) {
+			t.Logf("select %d: %x-%x fails\n", i, key, k)
+			t.Fail()
+		}
+	}
+	if _, _, ok := trie.Select(len(keys)); ok {
+		t.Log("select past the end should fail")
+		t.Fail()
+	}
+	if _, _, ok := trie.Select(-1); ok {
+		t.Log("select -1 should fail")
+		t.Fail()
+	}
+}
+
+func TestRank(t *testing.T) {
+	var containers = map[string]func() Container[int]{
+		"bytemap": NewByteMap[int],
+		"linkmap": NewLinkmap[int],
+		"artmap":  NewArtMap[int],
+	}
+	for name, container := range containers {
+		var trie = NewTrie(3, container)
+		var keys = make([][]byte, 0, 2000)
+		for i := 0; i < 2000; i++ {
+			k := []byte{byte(rand.Intn(8)), byte(rand.Intn(256)), byte(rand.Intn(256))}
+			if _, ok := trie.Get(k); !ok {
+				keys = append(keys, k)
+			}
+			trie.Set(k, i)
+		}
+		testRank(t, trie, keys)
+		for _, k := range keys[:1000] {
+			trie.Del(k)
+		}
+		testRank(t, trie, keys[1000:])
+		if r := trie.Rank([]byte{9, 0, 0}); r != len(keys)-1000 {
+			t.Logf("%s rank past the end: %d fails\n", name, r)
+			t.Fail()
+		}
+	}
+}
+
+func TestVarRank(t *testing.T) {
+	var trie = NewVarTrie(NewByteMap[int])
+	var keys = make([][]byte, 0, 2000)
+	for i := 0; i < 2000; i++ {
+		k := make([]byte, rand.Intn(4))
+		for j := range k {
+			k[j] = byte(rand.Intn(4))
+		}
+		if _, ok := trie.Get(k); !ok {
+			keys = append(keys, k)
+		}
+		trie.Set(k, i)
+	}
+	testRank(t, trie, keys)
+	rand.Shuffle(len(keys), func(i, j int) {
+		keys[i], keys[j] = keys[j], keys[i]
+	})
+	half := len(keys) / 2
+	for _, k := range keys[:half] {
+		trie.Del(k)
+	}
+	testRank(t, trie, keys[half:])
+}
+
+func TestCount(t *testing.T) {
+	var trie = NewTrie(3, NewNmap[string])
+	for i := 100; i < 1000; i += 7 {
+		key := []byte(strconv.Itoa(i))
+		trie.Set(key, string(key))
+	}
+	var keys = [][]byte{[]byte("000"), []byte("100"), []byte("101"), []byte("345"), []byte("506"), []byte("999"), nil}
+	var bounds = make([]Key, 0, 2*len(keys))
+	for _, k := range keys {
+		if k == nil {
+			bounds = append(bounds, Unbounded())
+			continue
+		}
+		bounds = append(bounds, Include(k), Exclude(k))
+	}
+	for _, l := range bounds {
+		for _, r := range bounds {
+			if n, want := trie.Count(l, r), len(trie.Scan(l, r)); n != want {
+				t.Logf("count %s-%s: %d-%d fails\n", l.key, r.key, n, want)
+				t.Fail()
+			}
+		}
+	}
+	for _, p := range []string{"", "1", "10", "34", "345", "35", "9"} {
+		if n, want := trie.CountPrefix([]byte(p)), len(trie.ScanPrefix([]byte(p))); n != want {
+			t.Logf("count prefix %s: %d-%d fails\n", p, n, want)
+			t.Fail()
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package trie
+
+// Clear removes all entries. Cursors and nodes of the old tree, including
+// those kept by Reset, are left to the garbage collector.
+func (t *Trie[V]) Clear() {
+	t.root = newTrieNode(0, t.container)
+	t.free = nil
+	t.head = nil
+	t.tail = nil
+	t.size = 0
+	t.version++
+}
+
+// Reset removes all entries like Clear, but keeps the interior nodes and
+// their containers in a free list that later Set calls draw from. Cursors
+// must not be used across a Reset.
+func (t *Trie[V]) Reset() {
+	t.recycle(t.root)
+	t.head = nil
+	t.tail = nil
+	t.size = 0
+	t.version++
+}
+
+// recycle empties the subtree of node, moving every non-root node that
+// owns a container to the free list.
+func (t *Trie[V]) recycle(node *TrieNode[V]) {
+	if node.children != nil {
+		for c := node.children.Head(); c != nil; {
+			next := node.children.Next(c.nodeKey)
+			t.recycle(c)
+			c = next
+		}
+		node.children.Clear()
+	}
+	children := node.children
+	*node = TrieNode[V]{children: children}
+	if node != t.root && children != nil {
+		t.free = append(t.free, node)
+	}
+}
+
+// newNode returns an empty interior node, reusing one kept by Reset when
+// there is one.
+func (t *Trie[V]) newNode(k byte) *TrieNode[V] {
+	if n := len(t.free); n > 0 {
+		node := t.free[n-1]
+		t.free[n-1] = nil
+		t.free = t.free[:n-1]
+		node.nodeKey = k
+		return node
+	}
+	return newTrieNode(k, t.container)
+}
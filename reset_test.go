@@ -0,0 +1,64 @@
+package trie
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestReset(t *testing.T) {
+	var trie = NewTrie(3, NewNmap[int])
+	var fill = func(from int) {
+		for i := from; i < 1000; i += 3 {
+			trie.Set([]byte(fmt.Sprintf("%03d", i)), i)
+		}
+	}
+	var check = func(from int) {
+		var n int
+		for k, v := range trie.All() {
+			if string(k) != fmt.Sprintf("%03d", v) || (v-from)%3 != 0 || trie.Rank(k) != n {
+				t.Logf("entry %s-%d fails\n", k, v)
+				t.Fail()
+			}
+			n++
+		}
+		if n != trie.Len() || n != (1000-from+2)/3 {
+			t.Logf("size %d-%d fails\n", n, trie.Len())
+			t.Fail()
+		}
+	}
+	fill(0)
+	version := trie.Version()
+	trie.Reset()
+	if trie.Len() != 0 || trie.head != nil || trie.root.headChild() != nil || trie.Version() <= version {
+		t.Log("trie should be empty after reset")
+		t.Fail()
+	}
+	if _, ok := trie.Get([]byte("000")); ok {
+		t.Log("get after reset should fail")
+		t.Fail()
+	}
+	// 10 nodes for the first digit, 100 for the first two
+	if len(trie.free) != 110 {
+		t.Logf("free list %d fails\n", len(trie.free))
+		t.Fail()
+	}
+	fill(1)
+	check(1)
+	if len(trie.free) != 0 {
+		t.Logf("free list %d after refill fails\n", len(trie.free))
+		t.Fail()
+	}
+
+	trie.Reset()
+	trie.Clear()
+	if trie.free != nil {
+		t.Logf("free list %d after clear fails\n", len(trie.free))
+		t.Fail()
+	}
+	if trie.Len() != 0 || trie.head != nil || trie.tail != nil || trie.root.headChild() != nil {
+		t.Log("trie should be empty after clear")
+		t.Fail()
+	}
+	fill(2)
+	check(2)
+}
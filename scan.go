@@ -1,8 +1,14 @@
 package trie
 
+import (
+	"bytes"
+	"cmp"
+)
+
 type Key struct {
-	key     []byte
-	include bool
+	key       []byte
+	include   bool
+	unbounded bool
 }
 
 func Include(key []byte) Key {
@@ -19,24 +25,123 @@ func Exclude(key []byte) Key {
 	}
 }
 
-func (t *Trie) Scan(l, r Key) []interface{} {
-	if t.head == nil {
-		return nil
+// Unbounded is a range endpoint past every key, on either side.
+func Unbounded() Key {
+	return Key{
+		unbounded: true,
 	}
-	lnode := t.gt(l.key, l.include)
-	rnode := t.lt(r.key, r.include)
+}
+
+func (t *Trie[V]) Scan(l, r Key) []V {
+	lnode, rnode := t.bounds(l, r)
 	if lnode == nil {
-		lnode = t.head
+		return nil
+	}
+	var vals = make([]V, 0, 8)
+	for cur := lnode; cur != nil; cur = cur.nextLeaf {
+		vals = append(vals, cur.val)
+		if cur == rnode {
+			break
+		}
+	}
+	return vals
+}
+
+// bounds returns the first and last entries between l and r, or nil nodes
+// when the range is empty.
+func (t *Trie[V]) bounds(l, r Key) (lnode, rnode *TrieNode[V]) {
+	if lnode = t.head; !l.unbounded {
+		lnode = t.gt(l.key, l.include)
+	}
+	if rnode = t.tail; !r.unbounded {
+		rnode = t.lt(r.key, r.include)
+	}
+	if lnode == nil || rnode == nil || t.compare(lnode.key, rnode.key) > 0 {
+		return nil, nil
+	}
+	return lnode, rnode
+}
+
+// compare orders two keys the way the trie does, by symbol order when the
+// container defines one and by byte value otherwise.
+func (t *Trie[V]) compare(a, b []byte) int {
+	o, ok := t.root.children.(symbolOrder)
+	if !ok {
+		return bytes.Compare(a, b)
+	}
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] != b[i] {
+			return cmp.Compare(o.Order(a[i]), o.Order(b[i]))
+		}
 	}
+	return cmp.Compare(len(a), len(b))
+}
+
+// ScanDesc is like Scan, but returns the values from hi down to lo.
+func (t *Trie[V]) ScanDesc(hi, lo Key) []V {
+	lnode, rnode := t.bounds(lo, hi)
 	if rnode == nil {
-		rnode = t.tail
+		return nil
 	}
-	var vals = make([]interface{}, 0, 8)
-	for cur := lnode; cur != nil; cur = cur.next {
+	var vals = make([]V, 0, 8)
+	for cur := rnode; cur != nil; cur = cur.prevLeaf {
 		vals = append(vals, cur.val)
-		if ok, _ := SliceEq(cur.key, rnode.key); ok {
-			return vals
+		if cur == lnode {
+			break
 		}
 	}
 	return vals
 }
+
+type Entry[V any] struct {
+	Key   []byte
+	Value V
+}
+
+// ScanPage returns at most limit entries between l and r, starting after
+// the page that produced the token after; pass nil for the first page. The
+// returned token is opaque, never empty while entries remain and nil once
+// the range is exhausted. Resuming looks the token up again, so keys may
+// change between pages. A limit <= 0 returns no entries and hands back
+// after unchanged, a token ScanPage did not produce returns nothing.
+func (t *Trie[V]) ScanPage(l, r Key, limit int, after []byte) ([]Entry[V], []byte) {
+	if limit <= 0 {
+		return nil, after
+	}
+	lnode, rnode := t.bounds(l, r)
+	if lnode == nil {
+		return nil, nil
+	}
+	if len(after) > 0 {
+		if after[0] != pageTokenV1 {
+			return nil, nil
+		}
+		anode := t.gt(after[1:], false)
+		if anode == nil || t.compare(anode.key, rnode.key) > 0 {
+			return nil, nil
+		}
+		if t.compare(anode.key, lnode.key) > 0 {
+			lnode = anode
+		}
+	}
+	var entries = make([]Entry[V], 0, 8)
+	for cur := lnode; cur != nil; cur = cur.nextLeaf {
+		entries = append(entries, Entry[V]{Key: cur.key, Value: cur.val})
+		if cur == rnode {
+			return entries, nil
+		}
+		if len(entries) == limit {
+			return entries, pageToken(cur.key)
+		}
+	}
+	return entries, nil
+}
+
+// pageTokenV1 leads every ScanPage token, so that a page ending on the
+// empty key still yields a token that is not empty.
+const pageTokenV1 = 1
+
+// pageToken returns the token of a page that ends on key.
+func pageToken(key []byte) []byte {
+	return append([]byte{pageTokenV1}, key...)
+}
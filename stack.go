@@ -1,20 +1,20 @@
 package trie
 
-type stackNode struct {
-	v    *TrieNode
-	next *stackNode
+type stackNode[V any] struct {
+	v    *TrieNode[V]
+	next *stackNode[V]
 }
 
-type Stack struct {
-	head *stackNode
+type Stack[V any] struct {
+	head *stackNode[V]
 	size int
 }
 
-func NewStack() *Stack {
-	return &Stack{}
+func NewStack[V any]() *Stack[V] {
+	return &Stack[V]{}
 }
 
-func (s *Stack) Pop() *TrieNode {
+func (s *Stack[V]) Pop() *TrieNode[V] {
 	if s.head == nil {
 		return nil
 	}
@@ -24,19 +24,19 @@ func (s *Stack) Pop() *TrieNode {
 	return v.v
 }
 
-func (s *Stack) Top() *TrieNode {
+func (s *Stack[V]) Top() *TrieNode[V] {
 	if s.head == nil {
 		return nil
 	}
 	return s.head.v
 }
 
-func (s *Stack) Len() int {
+func (s *Stack[V]) Len() int {
 	return s.size
 }
 
-func (s *Stack) Push(v *TrieNode) {
-	node := &stackNode{
+func (s *Stack[V]) Push(v *TrieNode[V]) {
+	node := &stackNode[V]{
 		v: v,
 	}
 	s.size++
@@ -47,8 +47,8 @@ func (s *Stack) Push(v *TrieNode) {
 	s.head.next = next
 }
 
-func (s *Stack) ToList() []*TrieNode {
-	var nodes = make([]*TrieNode, 0, s.Len())
+func (s *Stack[V]) ToList() []*TrieNode[V] {
+	var nodes = make([]*TrieNode[V], 0, s.Len())
 	var cur = s.head
 	for cur != nil {
 		nodes = append(nodes, cur.v)
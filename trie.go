@@ -1,302 +1,697 @@
 package trie
 
-import (
-	"strconv"
-)
-
-type Trie struct {
+// Trie is an ordered map of byte keys. With a positive keySize every key
+// must have exactly that length; with keySize 0 keys of any length share the
+// trie and are ordered lexicographically, a prefix before its extensions.
+type Trie[V any] struct {
 	keySize   int
-	root      *TrieNode
-	head      *TrieNode
-	tail      *TrieNode
-	container func() Container
+	root      *TrieNode[V]
+	head      *TrieNode[V]
+	tail      *TrieNode[V]
+	container func() Container[V]
 	size      int
+	version   uint64
+	// interior nodes kept by Reset
+	free []*TrieNode[V]
+	options
 }
 
-func NewTrie(keySize int, container func() Container) *Trie {
-	return &Trie{
+func NewTrie[V any](keySize int, container func() Container[V], opts ...Option) *Trie[V] {
+	t := &Trie[V]{
 		root:      newTrieNode(0, container),
 		keySize:   keySize,
 		container: container,
 	}
+	for _, opt := range opts {
+		opt(&t.options)
+	}
+	return t
+}
+
+// NewVarTrie returns a Trie that accepts keys of any length.
+func NewVarTrie[V any](container func() Container[V], opts ...Option) *Trie[V] {
+	return NewTrie(0, container, opts...)
 }
 
-type TrieNode struct {
-	prev     *TrieNode
-	next     *TrieNode
+type TrieNode[V any] struct {
+	// siblings in the parent container
+	prev *TrieNode[V]
+	next *TrieNode[V]
+	// entries in key order, only linked when hasVal
+	prevLeaf *TrieNode[V]
+	nextLeaf *TrieNode[V]
 	nodeKey  byte
-	key      []byte
-	val      interface{}
-	children Container
+	hasVal   bool
+	// length of the compressed edge after nodeKey, see edge
+	edgeLen uint32
+	key     []byte
+	val     V
+	version uint64
+	// entries in the subtree, including this node
+	count    int
+	children Container[V]
 }
 
-func newTrieNodeLeaf(k byte, key []byte, val interface{}) *TrieNode {
-	return &TrieNode{
+func newTrieNodeLeaf[V any](k byte, key []byte, val V) *TrieNode[V] {
+	return &TrieNode[V]{
 		nodeKey: k,
 		key:     key,
 		val:     val,
 	}
 }
 
-func newTrieNode(k byte, nodeContainer func() Container) *TrieNode {
+func newTrieNode[V any](k byte, nodeContainer func() Container[V]) *TrieNode[V] {
 	if nodeContainer == nil {
 		panic("container is nil")
 	}
-	return &TrieNode{
+	return &TrieNode[V]{
 		children: nodeContainer(),
 		nodeKey:  k,
 	}
 }
 
-func (node *TrieNode) Free() {
+func (node *TrieNode[V]) Free() {
 	if node == nil {
 		return
 	}
 	node.children = nil
+	node.edgeLen = 0
 	node.key = nil
 	node.next = nil
 	node.prev = nil
-	node.val = nil
+	node.nextLeaf = nil
+	node.prevLeaf = nil
+	var zero V
+	node.val = zero
+	node.hasVal = false
+	node.version = 0
+	node.count = 0
 	node = nil
 }
 
-func (t *Trie) Set(key []byte, val interface{}) {
-	if len(key) != t.keySize {
-		panic("key size should be " + strconv.Itoa(t.keySize))
+func (node *TrieNode[V]) headChild() *TrieNode[V] {
+	if node.children == nil {
+		return nil
 	}
+	return node.children.Head()
+}
 
-	cur := t.root
-	var ok bool
-	for level, nodeKey := range key {
-		var node *TrieNode
-		if node, ok = cur.children.Get(nodeKey); !ok {
-			if level == t.keySize-1 {
-				node = newTrieNodeLeaf(nodeKey, key, val)
-				t.size++
-			} else {
-				node = newTrieNode(nodeKey, t.container)
-			}
+func (node *TrieNode[V]) tailChild() *TrieNode[V] {
+	if node.children == nil {
+		return nil
+	}
+	return node.children.Tail()
+}
 
-			cur.children.Set(nodeKey, node)
-			if tail := cur.children.Tail(); tail == node {
-				if next := cur.next; next != nil {
-					if nextHead := next.children.Head(); nextHead != nil {
-						nextHead.prev = node
-						node.next = nextHead
-					}
-				}
-			}
-			if head := cur.children.Head(); head == node {
-				if prev := cur.prev; prev != nil {
-					if prevTail := prev.children.Tail(); prevTail != nil {
-						prevTail.next = node
-						node.prev = prevTail
-					}
-				}
-			}
-		} else {
-			node.val = val
-		}
-		cur = node
+// firstLeaf returns the smallest entry in the subtree of node.
+func firstLeaf[V any](node *TrieNode[V]) *TrieNode[V] {
+	for node != nil && !node.hasVal {
+		node = node.headChild()
 	}
+	return node
+}
 
-	if t.head == nil {
-		t.head = cur
-		t.tail = cur
-		return
+// lastLeaf returns the greatest entry in the subtree of node.
+func lastLeaf[V any](node *TrieNode[V]) *TrieNode[V] {
+	for {
+		tail := node.tailChild()
+		if tail == nil {
+			return node
+		}
+		node = tail
 	}
+}
 
-	if t.head.prev == cur {
-		t.head = cur
+// entry returns the key and value of node, ok is false for a nil node.
+func (node *TrieNode[V]) entry() (key []byte, val V, ok bool) {
+	if node == nil {
+		return key, val, false
 	}
-	if t.tail.next == cur {
-		t.tail = cur
+	return node.key, node.val, true
+}
+
+// check validates key against the key size and the symbols accepted by the
+// container, before anything in the trie is touched.
+func (t *Trie[V]) check(key []byte) error {
+	if t.keySize > 0 && len(key) != t.keySize {
+		return ErrKeySize{Size: len(key), Want: t.keySize}
 	}
+	return t.checkSymbols(key)
 }
-func (t *Trie) Get(key []byte) (interface{}, bool) {
-	if len(key) != t.keySize {
-		return nil, false
+
+// checkSymbols validates the symbols of key, any byte is valid in nibble
+// mode.
+func (t *Trie[V]) checkSymbols(key []byte) error {
+	if t.nibbles {
+		return nil
 	}
-	cur := t.root
-	var ok bool
-	for _, k := range key {
-		cur, ok = cur.children.Get(k)
-		if !ok {
-			return nil, false
+	for i, k := range key {
+		if !t.root.children.Accept(k) {
+			return ErrInvalidSymbol{Pos: i, Byte: k}
 		}
-		if len(cur.key) > 0 {
-			return cur.val, true
+	}
+	return nil
+}
+
+func (t *Trie[V]) Set(key []byte, val V) {
+	if err := t.TrySet(key, val); err != nil {
+		panic(err)
+	}
+}
+
+// TrySet is like Set, but returns ErrKeySize or ErrInvalidSymbol instead of
+// panicking. A rejected key leaves the trie unchanged.
+func (t *Trie[V]) TrySet(key []byte, val V) error {
+	if err := t.check(key); err != nil {
+		return err
+	}
+	if node := t.find(key); node != nil {
+		t.store(node, val)
+		return nil
+	}
+	path, _ := t.walk(key)
+	t.insert(path, key, val)
+	return nil
+}
+
+// fold returns key in the canonical form of a folding container, copying
+// it only when a symbol changes.
+func (t *Trie[V]) fold(key []byte) []byte {
+	f, ok := t.root.children.(symbolFolder)
+	if !ok {
+		return key
+	}
+	for i, k := range key {
+		if f.Fold(k) != k {
+			folded := append([]byte(nil), key...)
+			for j := i; j < len(folded); j++ {
+				folded[j] = f.Fold(folded[j])
+			}
+			return folded
 		}
 	}
-	return nil, false
+	return key
 }
 
-func (t *Trie) Del(key []byte) bool {
-	if len(key) != t.keySize {
-		return false
+// pathKey returns the symbols key is stored under: its canonical form, or
+// its nibbles in nibble mode.
+func (t *Trie[V]) pathKey(key []byte) []byte {
+	if t.nibbles {
+		return toNibbles(key)
+	}
+	return t.fold(key)
+}
+
+// walk descends along key as far as the trie goes, path holds the nodes
+// from the root down, each one matching a longer prefix of key. found
+// reports whether the last node holds the entry for key.
+func (t *Trie[V]) walk(key []byte) (path []*TrieNode[V], found bool) {
+	key = t.pathKey(key)
+	path = make([]*TrieNode[V], 1, len(key)+1)
+	path[0] = t.root
+	cur := t.root
+	var depth int
+	for depth < len(key) && cur.children != nil {
+		c, ok := cur.children.Get(key[depth])
+		if !ok || t.compareEdge(t.edge(c, depth), key[depth+1:]) != 0 {
+			break
+		}
+		depth += 1 + int(c.edgeLen)
+		path = append(path, c)
+		cur = c
 	}
+	return path, depth == len(key) && cur.hasVal
+}
+
+// find returns the node holding the entry for key, or nil. Unlike walk it
+// records no path, so lookups do not allocate.
+func (t *Trie[V]) find(key []byte) *TrieNode[V] {
+	key = t.pathKey(key)
 	cur := t.root
-	var stack = NewStack()
-	stack.Push(cur)
-	var ok bool
-	for _, k := range key {
-		cur, ok = cur.children.Get(k)
-		if !ok {
-			return false
+	var depth int
+	for depth < len(key) {
+		if cur.children == nil {
+			return nil
 		}
+		c, ok := cur.children.Get(key[depth])
+		if !ok || t.compareEdge(t.edge(c, depth), key[depth+1:]) != 0 {
+			return nil
+		}
+		depth += 1 + int(c.edgeLen)
+		cur = c
+	}
+	if !cur.hasVal {
+		return nil
+	}
+	return cur
+}
 
-		if len(cur.key) > 0 {
-			// delete leaf
-			stack.Top().children.Del(k)
-			break
+// insert adds the entry for key below the nodes returned by walk, creating
+// the missing nodes, and returns its node.
+func (t *Trie[V]) insert(path []*TrieNode[V], key []byte, val V) *TrieNode[V] {
+	// the trie owns its keys, so callers may reuse theirs
+	key = append(make([]byte, 0, len(key)), t.fold(key)...)
+	sym := key
+	if t.nibbles {
+		sym = toNibbles(key)
+	}
+	cur := path[len(path)-1]
+	level := pathDepth(path)
+	if t.compress && level < len(sym) {
+		if cur.children == nil {
+			cur.children = t.container()
+		}
+		if c, ok := cur.children.Get(sym[level]); ok {
+			cur = t.split(c, level, sym)
+			level += 1 + int(cur.edgeLen)
+			path = append(path, cur)
+		}
+		if level < len(sym) {
+			node := newTrieNodeLeaf(sym[level], key, val)
+			node.edgeLen = uint32(len(sym) - level - 1)
+			cur.children.Set(sym[level], node)
+			path = append(path, node)
+			cur = node
+			level = len(sym)
 		}
-		stack.Push(cur)
 	}
-	// delete parent
-	for {
-		cur := stack.Pop()
-		if cur.children.Head() == nil {
-			stack.Top().children.Del(cur.nodeKey)
+	for ; level < len(sym); level++ {
+		nodeKey := sym[level]
+		if cur.children == nil {
+			cur.children = t.container()
+		}
+		var node *TrieNode[V]
+		if level == len(sym)-1 {
+			node = newTrieNodeLeaf(nodeKey, key, val)
 		} else {
-			break
+			node = t.newNode(nodeKey)
+		}
+		cur.children.Set(nodeKey, node)
+		path = append(path, node)
+		cur = node
+	}
+	cur.key = key
+	cur.hasVal = true
+	t.store(cur, val)
+	for _, node := range path {
+		node.count++
+	}
+	t.size++
+	t.link(cur, t.prevLeaf(path))
+	return cur
+}
+
+// store sets the value of an entry and moves it to a new version.
+func (t *Trie[V]) store(node *TrieNode[V], val V) {
+	t.version++
+	node.val = val
+	node.version = t.version
+}
+
+// remove deletes the entry at the end of path, then the parents it leaves
+// empty, never the root.
+func (t *Trie[V]) remove(path []*TrieNode[V]) {
+	for _, node := range path {
+		node.count--
+	}
+	cur := path[len(path)-1]
+	t.unlink(cur)
+	t.size--
+	t.version++
+	defer t.rekey(path)
+	if cur == t.root || cur.headChild() != nil {
+		// interior entry, keep the node for its children
+		var zero V
+		if !t.compress {
+			cur.key = nil
 		}
+		cur.val = zero
+		cur.hasVal = false
+		cur.version = 0
+		t.compact(cur)
+		return
+	}
+	for level := len(path) - 2; level >= 0; level-- {
+		parent := path[level]
+		parent.children.Del(cur.nodeKey)
+		if parent == t.root || parent.hasVal || parent.children.Head() != nil {
+			t.compact(parent)
+			return
+		}
+		cur = parent
+	}
+}
+
+// rekey calls rekeyNode on the nodes of path below the root, bottom up.
+func (t *Trie[V]) rekey(path []*TrieNode[V]) {
+	for level := len(path) - 1; level > 0; level-- {
+		t.rekeyNode(path[level])
+	}
+}
+
+// rekeyNode points a node that holds no entry at the key of its first
+// child. With path compression such a node keeps a key to read its edge
+// from, and it must not be the key of a deleted entry, which the caller
+// may have been handed and may modify.
+func (t *Trie[V]) rekeyNode(node *TrieNode[V]) {
+	if !t.compress || node.hasVal {
+		return
+	}
+	if head := node.headChild(); head != nil {
+		node.key = head.key
+	}
+}
+
+// prevLeaf returns the greatest entry ordered before the last node of path,
+// which holds the nodes from the root down.
+func (t *Trie[V]) prevLeaf(path []*TrieNode[V]) *TrieNode[V] {
+	for level := len(path) - 1; level > 0; level-- {
+		parent := path[level-1]
+		if prev := parent.children.Prev(path[level].nodeKey); prev != nil {
+			return lastLeaf(prev)
+		}
+		if parent.hasVal {
+			return parent
+		}
+	}
+	return nil
+}
+
+// link inserts node into the leaf list right after prev, or at the head
+// when prev is nil.
+func (t *Trie[V]) link(node, prev *TrieNode[V]) {
+	var next *TrieNode[V]
+	if prev != nil {
+		next = prev.nextLeaf
+		prev.nextLeaf = node
+	} else {
+		next = t.head
+		t.head = node
+	}
+	if next != nil {
+		next.prevLeaf = node
+	} else {
+		t.tail = node
+	}
+	node.prevLeaf = prev
+	node.nextLeaf = next
+}
+
+// unlink removes node from the leaf list.
+func (t *Trie[V]) unlink(node *TrieNode[V]) {
+	if node.prevLeaf != nil {
+		node.prevLeaf.nextLeaf = node.nextLeaf
+	} else {
+		t.head = node.nextLeaf
+	}
+	if node.nextLeaf != nil {
+		node.nextLeaf.prevLeaf = node.prevLeaf
+	} else {
+		t.tail = node.prevLeaf
+	}
+	node.prevLeaf = nil
+	node.nextLeaf = nil
+}
+
+func (t *Trie[V]) Get(key []byte) (val V, ok bool) {
+	if t.check(key) != nil {
+		return val, false
 	}
-	return false
+	node := t.find(key)
+	if node == nil {
+		return val, false
+	}
+	return node.val, true
+}
+
+func (t *Trie[V]) Del(key []byte) (old V, found bool) {
+	old, found, _ = t.TryDel(key)
+	return old, found
 }
 
-func (t *Trie) Gt(key []byte) interface{} {
+// TryDel is like Del, but reports ErrKeySize or ErrInvalidSymbol for a key
+// that can never be in the trie.
+func (t *Trie[V]) TryDel(key []byte) (old V, found bool, err error) {
+	if err = t.check(key); err != nil {
+		return old, false, err
+	}
+	path, found := t.walk(key)
+	if !found {
+		return old, false, nil
+	}
+	old = path[len(path)-1].val
+	t.remove(path)
+	return old, true, nil
+}
+
+func (t *Trie[V]) Gt(key []byte) (val V) {
 	if node := t.gt(key, false); node != nil {
 		return node.val
 	}
-	return nil
+	return val
 }
 
-func (t *Trie) Gte(key []byte) interface{} {
+func (t *Trie[V]) Gte(key []byte) (val V) {
 	if node := t.gt(key, true); node != nil {
 		return node.val
 	}
-	return nil
+	return val
+}
+
+func (t *Trie[V]) GtEntry(key []byte) ([]byte, V, bool) {
+	return t.gt(key, false).entry()
 }
-func (t *Trie) gt(key []byte, e bool) *TrieNode {
-	key = t.PadRight(key)
+
+func (t *Trie[V]) GteEntry(key []byte) ([]byte, V, bool) {
+	return t.gt(key, true).entry()
+}
+func (t *Trie[V]) gt(key []byte, e bool) *TrieNode[V] {
+	key = t.pathKey(t.PadRight(key))
+	var path = make([]*TrieNode[V], 1, len(key)+1)
+	path[0] = t.root
 	cur := t.root
-	toNext := false
-	toHead := false
-	var level = 0
-	var stack = NewStack()
-	stack.Push(cur)
-	for level >= 0 && level < t.keySize && cur != nil {
-		nodeKey := key[level]
-		if toNext {
-			c := cur.children.Next(nodeKey)
-			if c == nil {
-				level--
-				toHead = true
-				cur = stack.Pop()
-				continue
+	var depth int
+	for depth < len(key) && cur.children != nil {
+		if !cur.children.Accept(key[depth]) {
+			if next := t.childAfter(cur.children, key[depth]); next != nil {
+				return firstLeaf(next)
 			}
-			cur = c
-		} else if toHead {
-			cur = cur.children.Head()
-		} else {
-			c, ok := cur.children.Get(nodeKey)
-			if !ok {
-				toNext = true
-				stack.Pop()
-				continue
-			} else {
+			break
+		}
+		if c, ok := cur.children.Get(key[depth]); ok {
+			cmp := t.compareEdge(t.edge(c, depth), key[depth+1:])
+			if cmp > 0 {
+				return firstLeaf(c)
+			}
+			if cmp == 0 {
+				depth += 1 + int(c.edgeLen)
+				path = append(path, c)
 				cur = c
+				continue
 			}
 		}
-
-		if len(cur.key) > 0 {
-			if e || toHead || toNext {
-				return cur
-			}
-			toNext = true
-			stack.Pop()
-			continue
+		if next := cur.children.Next(key[depth]); next != nil {
+			return firstLeaf(next)
+		}
+		break
+	}
+	if depth == len(key) {
+		if e && cur.hasVal {
+			return cur
+		}
+		if head := cur.headChild(); head != nil {
+			return firstLeaf(head)
+		}
+	}
+	for level := len(path) - 1; level > 0; level-- {
+		if next := path[level-1].children.Next(path[level].nodeKey); next != nil {
+			return firstLeaf(next)
 		}
-
-		level++
-		stack.Push(cur)
-		toNext = false
 	}
-
 	return nil
 }
 
-func (t *Trie) Lt(key []byte) interface{} {
+func (t *Trie[V]) Lt(key []byte) (val V) {
 	if node := t.lt(key, false); node != nil {
 		return node.val
 	}
-	return nil
+	return val
 }
-func (t *Trie) Lte(key []byte) interface{} {
+func (t *Trie[V]) Lte(key []byte) (val V) {
 	if node := t.lt(key, true); node != nil {
 		return node.val
 	}
-	return nil
+	return val
+}
+
+func (t *Trie[V]) LtEntry(key []byte) ([]byte, V, bool) {
+	return t.lt(key, false).entry()
 }
-func (t *Trie) lt(key []byte, e bool) *TrieNode {
-	key = t.PadRight(key)
+
+func (t *Trie[V]) LteEntry(key []byte) ([]byte, V, bool) {
+	return t.lt(key, true).entry()
+}
+func (t *Trie[V]) lt(key []byte, e bool) *TrieNode[V] {
+	key = t.pathKey(t.PadRight(key))
+	var path = make([]*TrieNode[V], 1, len(key)+1)
+	path[0] = t.root
 	cur := t.root
-	toPrev := false
-	toTail := false
-	var level = 0
-	var stack = NewStack()
-	stack.Push(cur)
-	for level >= 0 && level < t.keySize && cur != nil {
-		nodeKey := key[level]
-		if toPrev {
-			c := cur.children.Prev(nodeKey)
-			if c == nil {
-				level--
-				toTail = true
-				cur = stack.Pop()
-				continue
+	var depth int
+	for depth < len(key) && cur.children != nil {
+		if !cur.children.Accept(key[depth]) {
+			if prev := t.childBefore(cur.children, key[depth]); prev != nil {
+				return lastLeaf(prev)
 			}
-			cur = c
-		} else if toTail {
-			cur = cur.children.Tail()
-		} else {
-			c, ok := cur.children.Get(nodeKey)
-			if !ok {
-				toPrev = true
-				stack.Pop()
-				continue
-			} else {
+			break
+		}
+		if c, ok := cur.children.Get(key[depth]); ok {
+			cmp := t.compareEdge(t.edge(c, depth), key[depth+1:])
+			if cmp < 0 {
+				return lastLeaf(c)
+			}
+			if cmp == 0 {
+				depth += 1 + int(c.edgeLen)
+				path = append(path, c)
 				cur = c
+				continue
 			}
 		}
+		if prev := cur.children.Prev(key[depth]); prev != nil {
+			return lastLeaf(prev)
+		}
+		break
+	}
+	// a node above the end of key holds a proper prefix of it
+	if (depth < len(key) || e) && cur.hasVal {
+		return cur
+	}
+	return t.prevLeaf(path)
+}
 
-		if len(cur.key) > 0 {
-			if e || toTail || toPrev {
-				return cur
+// childAfter returns the first child sorting after k, a symbol the
+// container does not accept. Such a symbol sorts by byte value among the
+// accepted ones, or before the whole alphabet when the container orders
+// its symbols, as in compare.
+func (t *Trie[V]) childAfter(children Container[V], k byte) *TrieNode[V] {
+	if _, ok := children.(symbolOrder); ok {
+		return children.Head()
+	}
+	for s := int(k) + 1; s < 256; s++ {
+		if t.canonical(children, byte(s)) {
+			if c, ok := children.Get(byte(s)); ok {
+				return c
 			}
-			toPrev = true
-			stack.Pop()
-			continue
+			return children.Next(byte(s))
 		}
-		level++
-		stack.Push(cur)
-		toPrev = false
 	}
+	return nil
+}
 
+// childBefore is like childAfter, but returns the last child sorting
+// before k.
+func (t *Trie[V]) childBefore(children Container[V], k byte) *TrieNode[V] {
+	if _, ok := children.(symbolOrder); ok {
+		return nil
+	}
+	for s := int(k) - 1; s >= 0; s-- {
+		if t.canonical(children, byte(s)) {
+			if c, ok := children.Get(byte(s)); ok {
+				return c
+			}
+			return children.Prev(byte(s))
+		}
+	}
 	return nil
 }
 
-func (t *Trie) Foreach(f func(key []byte, val interface{})) {
-	for cur := t.head; cur != nil; cur = cur.next {
+// canonical reports whether k is a symbol keys are stored under, accepted
+// by the container and not folded to another symbol.
+func (t *Trie[V]) canonical(children Container[V], k byte) bool {
+	if !children.Accept(k) {
+		return false
+	}
+	f, ok := children.(symbolFolder)
+	return !ok || f.Fold(k) == k
+}
+
+func (t *Trie[V]) First() ([]byte, V, bool) {
+	return t.head.entry()
+}
+
+func (t *Trie[V]) Last() ([]byte, V, bool) {
+	return t.tail.entry()
+}
+
+// PopFirst deletes and returns the entry with the smallest key.
+func (t *Trie[V]) PopFirst() (key []byte, val V, ok bool) {
+	if t.head == nil {
+		return key, val, false
+	}
+	key = t.head.key
+	val, ok = t.Del(key)
+	return key, val, ok
+}
+
+// PopLast deletes and returns the entry with the greatest key.
+func (t *Trie[V]) PopLast() (key []byte, val V, ok bool) {
+	if t.tail == nil {
+		return key, val, false
+	}
+	key = t.tail.key
+	val, ok = t.Del(key)
+	return key, val, ok
+}
+
+// Foreach calls f for every entry in key order. The key passed to f, here
+// and in Walk, the iterators and the Cursor, belongs to the trie: it may be
+// retained after f returns, but must not be modified.
+func (t *Trie[V]) Foreach(f func(key []byte, val V)) {
+	for cur := t.head; cur != nil; cur = cur.nextLeaf {
+		f(cur.key, cur.val)
+	}
+}
+
+func (t *Trie[V]) ForeachReverse(f func(key []byte, val V)) {
+	for cur := t.tail; cur != nil; cur = cur.prevLeaf {
 		f(cur.key, cur.val)
 	}
 }
 
-func (t *Trie) PadRight(key []byte) []byte {
+// Walk is like Foreach, but stops as soon as f returns false.
+func (t *Trie[V]) Walk(f func(key []byte, val V) bool) {
+	for cur := t.head; cur != nil; cur = cur.nextLeaf {
+		if !f(cur.key, cur.val) {
+			return
+		}
+	}
+}
+
+// Keys returns copies of all keys in order.
+func (t *Trie[V]) Keys() [][]byte {
+	var keys = make([][]byte, 0, t.size)
+	for cur := t.head; cur != nil; cur = cur.nextLeaf {
+		keys = append(keys, append([]byte(nil), cur.key...))
+	}
+	return keys
+}
+
+// Values returns all values in key order.
+func (t *Trie[V]) Values() []V {
+	var vals = make([]V, 0, t.size)
+	for cur := t.head; cur != nil; cur = cur.nextLeaf {
+		vals = append(vals, cur.val)
+	}
+	return vals
+}
+
+func (t *Trie[V]) PadRight(key []byte) []byte {
+	if t.keySize == 0 {
+		return key
+	}
 	if size := len(key); size < t.keySize {
 		var fullKey = make([]byte, t.keySize)
 		pad := t.root.children.Pad()
-		for i := size - 1; i < t.keySize; i++ {
+		for i := size; i < t.keySize; i++ {
 			fullKey[i] = pad
 		}
 		copy(fullKey[:size], key)
@@ -305,6 +700,6 @@ func (t *Trie) PadRight(key []byte) []byte {
 	return key[:t.keySize]
 }
 
-func (t *Trie) Len() int {
+func (t *Trie[V]) Len() int {
 	return t.size
 }
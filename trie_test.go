@@ -1,8 +1,14 @@
 package trie
 
 import (
+	"bytes"
+	"errors"
 	"fmt"
 	"log"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
@@ -13,7 +19,7 @@ import (
 var size = 10000000
 
 func TestBytesTire(t *testing.T) {
-	var trie = NewTrie(12, NewByteMap)
+	var trie = NewTrie(12, NewByteMap[[]byte])
 	var keys = make([][]byte, 0, size)
 	start := time.Now()
 	for i := 0; i < size; i++ {
@@ -30,7 +36,7 @@ func TestBytesTire(t *testing.T) {
 			t.Fail()
 			continue
 		}
-		if ok, i := SliceEq(v.([]byte), k); !ok {
+		if ok, i := SliceEq(v, k); !ok {
 			t.Logf("key %d: %s-%s fails\n", i, k, v)
 			t.Fail()
 		}
@@ -43,7 +49,7 @@ func TestBytesTire(t *testing.T) {
 }
 
 func TestHexTire(t *testing.T) {
-	var trie = NewTrie(24, NewHexMap)
+	var trie = NewTrie(24, NewHexMap[[]byte])
 	var keys = make([][]byte, 0, size)
 	start := time.Now()
 	for i := 0; i < size; i++ {
@@ -60,7 +66,7 @@ func TestHexTire(t *testing.T) {
 			t.Fail()
 			continue
 		}
-		if ok, i := SliceEq(v.([]byte), k); !ok {
+		if ok, i := SliceEq(v, k); !ok {
 			t.Logf("key %d: %s-%s fails\n", i, k, v)
 			t.Fail()
 		}
@@ -75,7 +81,7 @@ func TestHexTire(t *testing.T) {
 }
 
 func TestMapTire(t *testing.T) {
-	var trie = NewTrie(12, NewLinkmap)
+	var trie = NewTrie(12, NewLinkmap[[]byte])
 	var keys = make([][]byte, 0, size)
 	start := time.Now()
 	for i := 0; i < size; i++ {
@@ -92,7 +98,37 @@ func TestMapTire(t *testing.T) {
 			t.Fail()
 			continue
 		}
-		if ok, i := SliceEq(v.([]byte), k); !ok {
+		if ok, i := SliceEq(v, k); !ok {
+			t.Logf("key %d: %s-%s fails\n", i, k, v)
+			t.Fail()
+		}
+	}
+	if trie.Len() != len(keys) {
+		t.Log("size not eq")
+		t.Fail()
+	}
+	log.Println("total cost", time.Since(start).Seconds())
+}
+
+func TestArtTire(t *testing.T) {
+	var trie = NewTrie(12, NewArtMap[[]byte])
+	var keys = make([][]byte, 0, size)
+	start := time.Now()
+	for i := 0; i < size; i++ {
+		key := primitive.NewObjectID()
+		k := key[:]
+		keys = append(keys, k)
+		trie.Set(k, k)
+	}
+	log.Println("set cost", time.Since(start).Seconds())
+	for _, k := range keys {
+		v, ok := trie.Get(k)
+		if !ok {
+			t.Logf("no key:%v fail\n", k)
+			t.Fail()
+			continue
+		}
+		if ok, i := SliceEq(v, k); !ok {
 			t.Logf("key %d: %s-%s fails\n", i, k, v)
 			t.Fail()
 		}
@@ -164,7 +200,7 @@ func TestSkipList(t *testing.T) {
 	log.Println("total cost", time.Since(start).Seconds())
 }
 func TestGt(t *testing.T) {
-	var trie = NewTrie(3, NewNmap)
+	var trie = NewTrie(3, NewNmap[[]byte])
 	for i := 101; i < 200; i += 10 {
 		key := []byte(fmt.Sprint(i))
 		trie.Set(key, key)
@@ -174,7 +210,7 @@ func TestGt(t *testing.T) {
 }
 
 func TestLt(t *testing.T) {
-	var trie = NewTrie(3, NewNmap)
+	var trie = NewTrie(3, NewNmap[[]byte])
 	for i := 101; i < 200; i += 10 {
 		key := []byte(fmt.Sprint(i))
 		trie.Set(key, key)
@@ -184,7 +220,7 @@ func TestLt(t *testing.T) {
 }
 
 func TestScan(t *testing.T) {
-	var trie = NewTrie(3, NewNmap)
+	var trie = NewTrie(3, NewNmap[[]byte])
 	for i := 101; i < 200; i += 10 {
 		key := []byte(fmt.Sprint(i))
 		trie.Set(key, key)
@@ -195,4 +231,598 @@ func TestScan(t *testing.T) {
 		fmt.Printf("%s\n", v)
 	}
 
+	var cases = []struct {
+		l, r Key
+		want string
+	}{
+		{Unbounded(), Exclude([]byte("121")), "[101 111]"},
+		{Exclude([]byte("171")), Unbounded(), "[181 191]"},
+		{Unbounded(), Unbounded(), "[101 111 121 141 151 161 171 181 191]"},
+		{Include([]byte("192")), Unbounded(), "[]"},
+		{Unbounded(), Exclude([]byte("101")), "[]"},
+		{Include([]byte("200")), Include([]byte("300")), "[]"},
+		{Include([]byte("000")), Include([]byte("100")), "[]"},
+		{Include([]byte("151")), Include([]byte("121")), "[]"},
+		{Exclude([]byte("121")), Exclude([]byte("141")), "[]"},
+	}
+	for _, c := range cases {
+		if vals := trie.Scan(c.l, c.r); fmt.Sprintf("%s", vals) != c.want {
+			t.Logf("scan %s-%s: %s fails\n", c.l.key, c.r.key, vals)
+			t.Fail()
+		}
+	}
+}
+
+func TestScanDesc(t *testing.T) {
+	var trie = NewTrie(3, NewNmap[string])
+	for i := 101; i < 200; i += 10 {
+		key := []byte(fmt.Sprint(i))
+		trie.Set(key, string(key))
+	}
+	trie.Del([]byte("131"))
+	vals := trie.ScanDesc(Exclude([]byte("171")), Include([]byte("121")))
+	if fmt.Sprint(vals) != "[161 151 141 121]" {
+		t.Logf("scan desc: %v fails\n", vals)
+		t.Fail()
+	}
+	vals = trie.ScanDesc(Include([]byte("121")), Include([]byte("161")))
+	if len(vals) != 0 {
+		t.Logf("scan desc crossed: %v fails\n", vals)
+		t.Fail()
+	}
+	var keys []string
+	trie.ForeachReverse(func(key []byte, val string) {
+		keys = append(keys, string(key))
+	})
+	if fmt.Sprint(keys) != "[191 181 171 161 151 141 121 111 101]" {
+		t.Logf("foreach reverse: %v fails\n", keys)
+		t.Fail()
+	}
+}
+
+func TestVarTrie(t *testing.T) {
+	var trie = NewVarTrie(NewByteMap[string])
+	var keys = []string{"b", "abc", "", "a", "ba", "ab", "abd", "c"}
+	for _, k := range keys {
+		trie.Set([]byte(k), k)
+	}
+	var sorted = []string{"", "a", "ab", "abc", "abd", "b", "ba", "c"}
+	var got = make([]string, 0, len(sorted))
+	trie.Foreach(func(key []byte, val string) {
+		got = append(got, val)
+	})
+	if fmt.Sprint(got) != fmt.Sprint(sorted) {
+		t.Logf("foreach: %v fails\n", got)
+		t.Fail()
+	}
+	for _, k := range keys {
+		if v, ok := trie.Get([]byte(k)); !ok || v != k {
+			t.Logf("get %q: %q fails\n", k, v)
+			t.Fail()
+		}
+	}
+	if _, ok := trie.Get([]byte("bab")); ok {
+		t.Log("get bab should fail")
+		t.Fail()
+	}
+	var cases = []struct {
+		key              string
+		gt, gte, lt, lte string
+	}{
+		{"ab", "abc", "ab", "a", "ab"},
+		{"abb", "abc", "abc", "ab", "ab"},
+		{"abz", "b", "b", "abd", "abd"},
+		{"bb", "c", "c", "ba", "ba"},
+		{"a", "ab", "a", "", "a"},
+	}
+	for _, c := range cases {
+		k := []byte(c.key)
+		if v := trie.Gt(k); v != c.gt {
+			t.Logf("gt %s: %q fails\n", c.key, v)
+			t.Fail()
+		}
+		if v := trie.Gte(k); v != c.gte {
+			t.Logf("gte %s: %q fails\n", c.key, v)
+			t.Fail()
+		}
+		if v := trie.Lt(k); v != c.lt {
+			t.Logf("lt %s: %q fails\n", c.key, v)
+			t.Fail()
+		}
+		if v := trie.Lte(k); v != c.lte {
+			t.Logf("lte %s: %q fails\n", c.key, v)
+			t.Fail()
+		}
+	}
+
+	trie.Del([]byte("ab"))
+	trie.Del([]byte("ba"))
+	if _, ok := trie.Get([]byte("ab")); ok {
+		t.Log("get ab after del should fail")
+		t.Fail()
+	}
+	vals := trie.Scan(Include([]byte("a")), Include([]byte("b")))
+	if fmt.Sprint(vals) != fmt.Sprint([]string{"a", "abc", "abd", "b"}) {
+		t.Logf("scan: %v fails\n", vals)
+		t.Fail()
+	}
+}
+
+func TestTrySet(t *testing.T) {
+	var trie = NewTrie(3, NewHexMap[string])
+	var sizeErr ErrKeySize
+	if err := trie.TrySet([]byte("12"), "12"); !errors.As(err, &sizeErr) || sizeErr.Size != 2 || sizeErr.Want != 3 {
+		t.Logf("key size: %v fails\n", err)
+		t.Fail()
+	}
+	var symErr ErrInvalidSymbol
+	for _, k := range []string{"12g", "1A2", ":00"} {
+		if err := trie.TrySet([]byte(k), k); !errors.As(err, &symErr) || symErr.Byte != k[symErr.Pos] {
+			t.Logf("symbol %s: %v fails\n", k, err)
+			t.Fail()
+		}
+	}
+	if msg := (ErrInvalidSymbol{Pos: 1, Byte: 0xff}).Error(); msg != `trie: invalid symbol "\xff" at 1` {
+		t.Logf("symbol error %s fails\n", msg)
+		t.Fail()
+	}
+	if trie.Len() != 0 || trie.root.children.Head() != nil {
+		t.Log("rejected keys should not change the trie")
+		t.Fail()
+	}
+	if err := trie.TrySet([]byte("a00"), "a00"); err != nil {
+		t.Logf("set a00: %v fails\n", err)
+		t.Fail()
+	}
+	if _, ok := trie.Get([]byte(":00")); ok {
+		t.Log("get :00 should fail")
+		t.Fail()
+	}
+	if _, ok, err := trie.TryDel([]byte("a0g")); ok || !errors.As(err, &symErr) {
+		t.Logf("del a0g: %v fails\n", err)
+		t.Fail()
+	}
+	if trie.Len() != 1 {
+		t.Log("size not eq")
+		t.Fail()
+	}
+}
+
+func TestDel(t *testing.T) {
+	var trie = NewTrie(3, NewNmap[string])
+	var keys = make([]string, 0, 100)
+	for i := 100; i < 200; i++ {
+		key := fmt.Sprint(i)
+		keys = append(keys, key)
+		trie.Set([]byte(key), key)
+	}
+	if _, ok := trie.Del([]byte("200")); ok {
+		t.Log("del 200 should fail")
+		t.Fail()
+	}
+	rand.Shuffle(len(keys), func(i, j int) {
+		keys[i], keys[j] = keys[j], keys[i]
+	})
+	for i, k := range keys {
+		old, ok := trie.Del([]byte(k))
+		if !ok || old != k {
+			t.Logf("del %s: %v-%s fails\n", k, ok, old)
+			t.Fail()
+		}
+		if _, ok := trie.Del([]byte(k)); ok {
+			t.Logf("del %s twice fails\n", k)
+			t.Fail()
+		}
+		if trie.Len() != len(keys)-i-1 {
+			t.Logf("del %s: size %d fails\n", k, trie.Len())
+			t.Fail()
+		}
+		var first, prev []byte
+		var n int
+		trie.Foreach(func(key []byte, val string) {
+			if prev != nil && string(prev) >= string(key) {
+				t.Logf("foreach %s after %s fails\n", key, prev)
+				t.Fail()
+			}
+			if first == nil {
+				first = key
+			}
+			prev = key
+			n++
+		})
+		if n != trie.Len() {
+			t.Logf("foreach %d entries fails\n", n)
+			t.Fail()
+		}
+		if n > 0 && (string(trie.head.key) != string(first) || string(trie.tail.key) != string(prev)) {
+			t.Log("head or tail not eq")
+			t.Fail()
+		}
+	}
+	if trie.head != nil || trie.tail != nil || trie.root.children.Head() != nil {
+		t.Log("trie should be empty")
+		t.Fail()
+	}
+	trie.Set([]byte("123"), "123")
+	if v, ok := trie.Get([]byte("123")); !ok || v != "123" || trie.Len() != 1 {
+		t.Log("set after del fails")
+		t.Fail()
+	}
+}
+
+func TestScanPage(t *testing.T) {
+	var trie = NewTrie(3, NewNmap[string])
+	for i := 100; i < 200; i++ {
+		key := []byte(fmt.Sprint(i))
+		trie.Set(key, string(key))
+	}
+	var l, r = Include([]byte("110")), Exclude([]byte("150"))
+	var vals []string
+	var token []byte
+	var pages int
+	for {
+		var entries []Entry[string]
+		entries, token = trie.ScanPage(l, r, 7, token)
+		pages++
+		for _, e := range entries {
+			vals = append(vals, e.Value)
+		}
+		if pages == 2 {
+			// deleting and inserting between pages must not skip or repeat
+			trie.Del([]byte("124"))
+			trie.Del(entries[len(entries)-1].Key)
+			trie.Set([]byte("100"), "100")
+		}
+		if token == nil {
+			break
+		}
+	}
+	if pages != 6 || len(vals) != 39 {
+		t.Logf("pages %d, %d values fails\n", pages, len(vals))
+		t.Fail()
+	}
+	for i := 1; i < len(vals); i++ {
+		if vals[i-1] >= vals[i] {
+			t.Logf("page %s after %s fails\n", vals[i], vals[i-1])
+			t.Fail()
+		}
+	}
+	if vals[0] != "110" || vals[len(vals)-1] != "149" {
+		t.Logf("page bounds %s-%s fails\n", vals[0], vals[len(vals)-1])
+		t.Fail()
+	}
+	if entries, token := trie.ScanPage(l, r, 10, pageToken([]byte("149"))); len(entries) != 0 || token != nil {
+		t.Log("page after the range should be empty")
+		t.Fail()
+	}
+	for _, limit := range []int{0, -1} {
+		if entries, token := trie.ScanPage(l, r, limit, []byte("120")); len(entries) != 0 || string(token) != "120" {
+			t.Logf("limit %d: %d entries fails\n", limit, len(entries))
+			t.Fail()
+		}
+	}
+
+	// a page may end on the empty key
+	var vtrie = NewVarTrie(NewByteMap[int])
+	vtrie.Set([]byte{}, 0)
+	vtrie.Set([]byte{1}, 1)
+	var keys [][]byte
+	token = nil
+	for pages = 0; pages < 3; pages++ {
+		var entries []Entry[int]
+		entries, token = vtrie.ScanPage(Unbounded(), Unbounded(), 1, token)
+		for _, e := range entries {
+			keys = append(keys, e.Key)
+		}
+		if token == nil {
+			break
+		}
+	}
+	if fmt.Sprint(keys) != "[[] [1]]" {
+		t.Logf("var pages %v fails\n", keys)
+		t.Fail()
+	}
+}
+
+func TestEntry(t *testing.T) {
+	var trie = NewTrie(3, NewNmap[*string])
+	for i := 101; i < 200; i += 10 {
+		key := []byte(fmt.Sprint(i))
+		trie.Set(key, nil)
+	}
+	var cases = []struct {
+		name string
+		f    func([]byte) ([]byte, *string, bool)
+		key  string
+		want string
+	}{
+		{"gt", trie.GtEntry, "111", "121"},
+		{"gte", trie.GteEntry, "111", "111"},
+		{"lt", trie.LtEntry, "111", "101"},
+		{"lte", trie.LteEntry, "115", "111"},
+		{"gt", trie.GtEntry, "191", ""},
+		{"gte", trie.GteEntry, "192", ""},
+		{"lt", trie.LtEntry, "101", ""},
+		{"lte", trie.LteEntry, "100", ""},
+	}
+	for _, c := range cases {
+		key, val, ok := c.f([]byte(c.key))
+		if ok != (c.want != "") || string(key) != c.want || val != nil {
+			t.Logf("%s %s: %s-%v fails\n", c.name, c.key, key, ok)
+			t.Fail()
+		}
+	}
+}
+
+func TestInvalidBound(t *testing.T) {
+	var trie = NewTrie(3, NewNmap[string])
+	trie.Set([]byte("123"), "123")
+	var cases = []struct {
+		name string
+		f    func([]byte) ([]byte, string, bool)
+		key  string
+		want string
+	}{
+		{"gte", trie.GteEntry, "/00", "123"},
+		{"lt", trie.LtEntry, "/00", ""},
+		{"lt", trie.LtEntry, ":00", "123"},
+		{"gt", trie.GtEntry, ":00", ""},
+		{"gt", trie.GtEntry, "12/", "123"},
+		{"lt", trie.LtEntry, "12:", "123"},
+		{"gt", trie.GtEntry, "12:", ""},
+	}
+	for _, c := range cases {
+		key, _, ok := c.f([]byte(c.key))
+		if ok != (c.want != "") || string(key) != c.want {
+			t.Logf("%s %s: %s-%v fails\n", c.name, c.key, key, ok)
+			t.Fail()
+		}
+	}
+	if vals := trie.Scan(Unbounded(), Include([]byte(":"))); fmt.Sprint(vals) != "[123]" {
+		t.Logf("scan to ':': %v fails\n", vals)
+		t.Fail()
+	}
+	if n := trie.Count(Include([]byte("/")), Unbounded()); n != 1 {
+		t.Logf("count from '/': %d fails\n", n)
+		t.Fail()
+	}
+
+	var vtrie = NewVarTrie(NewHexMap[string])
+	for _, k := range []string{"5", "5a", "7"} {
+		vtrie.Set([]byte(k), k)
+	}
+	if key, _, ok := vtrie.LtEntry([]byte("5x")); !ok || string(key) != "5a" {
+		t.Logf("lt 5x: %s-%v fails\n", key, ok)
+		t.Fail()
+	}
+	if key, _, ok := vtrie.GtEntry([]byte("5A")); !ok || string(key) != "5a" {
+		t.Logf("gt 5A: %s-%v fails\n", key, ok)
+		t.Fail()
+	}
+	if key, _, ok := vtrie.LtEntry([]byte("5A")); !ok || string(key) != "5" {
+		t.Logf("lt 5A: %s-%v fails\n", key, ok)
+		t.Fail()
+	}
+
+	var atrie = NewVarTrie(NewAlphabetMap[string]("zyx"))
+	for _, k := range []string{"z", "zx", "x"} {
+		atrie.Set([]byte(k), k)
+	}
+	// symbols outside an alphabet sort before it
+	if key, _, ok := atrie.GtEntry([]byte("za")); !ok || string(key) != "zx" {
+		t.Logf("gt za: %s-%v fails\n", key, ok)
+		t.Fail()
+	}
+	if key, _, ok := atrie.LtEntry([]byte("za")); !ok || string(key) != "z" {
+		t.Logf("lt za: %s-%v fails\n", key, ok)
+		t.Fail()
+	}
+}
+
+func TestPop(t *testing.T) {
+	var trie = NewTrie(12, NewByteMap[int])
+	var keys = make([][]byte, 0, 1000)
+	for i := 0; i < 1000; i++ {
+		key := primitive.NewObjectID()
+		keys = append(keys, key[:])
+		trie.Set(key[:], i)
+	}
+	if key, val, ok := trie.First(); !ok || val != 0 || !bytes.Equal(key, keys[0]) {
+		t.Logf("first: %x-%d fails\n", key, val)
+		t.Fail()
+	}
+	if key, val, ok := trie.Last(); !ok || val != 999 || !bytes.Equal(key, keys[999]) {
+		t.Logf("last: %x-%d fails\n", key, val)
+		t.Fail()
+	}
+	for i := 0; i < 500; i++ {
+		if _, val, ok := trie.PopFirst(); !ok || val != i {
+			t.Logf("pop first %d: %d fails\n", i, val)
+			t.Fail()
+		}
+		if _, val, ok := trie.PopLast(); !ok || val != 999-i {
+			t.Logf("pop last %d: %d fails\n", i, val)
+			t.Fail()
+		}
+	}
+	if _, _, ok := trie.PopFirst(); ok || trie.Len() != 0 {
+		t.Log("pop first on empty trie fails")
+		t.Fail()
+	}
+	if _, _, ok := trie.Last(); ok {
+		t.Log("last on empty trie fails")
+		t.Fail()
+	}
+}
+
+func TestWalk(t *testing.T) {
+	var trie = NewTrie(3, NewNmap[int])
+	var key = make([]byte, 3)
+	for i := 100; i < 200; i += 10 {
+		copy(key, strconv.Itoa(i))
+		trie.Set(key, i)
+	}
+	var n int
+	trie.Walk(func(key []byte, val int) bool {
+		n++
+		return val < 150
+	})
+	if n != 6 {
+		t.Logf("walk %d fails\n", n)
+		t.Fail()
+	}
+	keys := trie.Keys()
+	if fmt.Sprintf("%s", keys) != "[100 110 120 130 140 150 160 170 180 190]" {
+		t.Logf("keys: %s fails\n", keys)
+		t.Fail()
+	}
+	keys[0][0] = '9'
+	if _, ok := trie.Get([]byte("100")); !ok {
+		t.Log("keys should be copies")
+		t.Fail()
+	}
+	if vals := trie.Values(); fmt.Sprint(vals) != "[100 110 120 130 140 150 160 170 180 190]" {
+		t.Logf("values: %v fails\n", vals)
+		t.Fail()
+	}
+}
+
+func TestAlphabetTrie(t *testing.T) {
+	var alphabet = "zyxCBA987"
+	var less = func(a, b string) bool {
+		for i := 0; i < len(a) && i < len(b); i++ {
+			if a[i] != b[i] {
+				return strings.IndexByte(alphabet, a[i]) < strings.IndexByte(alphabet, b[i])
+			}
+		}
+		return len(a) < len(b)
+	}
+	var newKey = func(n int) []byte {
+		k := make([]byte, n)
+		for i := range k {
+			k[i] = alphabet[rand.Intn(len(alphabet))]
+		}
+		return k
+	}
+	for _, trie := range []*Trie[string]{
+		NewTrie(3, NewAlphabetMap[string](alphabet)),
+		NewVarTrie(NewAlphabetMap[string](alphabet), WithPathCompression()),
+	} {
+		var keys []string
+		for i := 0; i < 300; i++ {
+			k := newKey(3)
+			if trie.keySize == 0 {
+				k = k[:rand.Intn(4)]
+			}
+			if _, ok := trie.Get(k); !ok {
+				keys = append(keys, string(k))
+			}
+			trie.Set(k, string(k))
+		}
+		sort.Slice(keys, func(i, j int) bool { return less(keys[i], keys[j]) })
+		if got := trie.Values(); strings.Join(got, ",") != strings.Join(keys, ",") {
+			t.Logf("order %v fails\n", got)
+			t.Fail()
+		}
+		for i := 0; i < 200; i++ {
+			q := string(newKey(3))
+			var want string
+			var n int
+			for _, k := range keys {
+				if less(k, q) {
+					want = k
+					n++
+				}
+			}
+			if got := trie.Lt([]byte(q)); got != want || trie.Rank([]byte(q)) != n {
+				t.Logf("lt %s: %s-%s fails\n", q, got, want)
+				t.Fail()
+			}
+			hi := string(newKey(3))
+			var count int
+			for _, k := range keys {
+				if !less(k, q) && less(k, hi) {
+					count++
+				}
+			}
+			if got := trie.Scan(Include([]byte(q)), Exclude([]byte(hi))); len(got) != count {
+				t.Logf("scan %s-%s: %d-%d fails\n", q, hi, len(got), count)
+				t.Fail()
+			}
+		}
+		var pages []string
+		var after []byte
+		for {
+			var page []Entry[string]
+			page, after = trie.ScanPage(Unbounded(), Unbounded(), 7, after)
+			for _, e := range page {
+				pages = append(pages, e.Value)
+			}
+			if after == nil {
+				break
+			}
+		}
+		if strings.Join(pages, ",") != strings.Join(keys, ",") {
+			t.Logf("pages %v fails\n", pages)
+			t.Fail()
+		}
+	}
+}
+
+func TestFoldTrie(t *testing.T) {
+	for _, trie := range []*Trie[int]{
+		NewTrie(24, NewFoldHexMap[int]),
+		NewVarTrie(NewFoldHexMap[int], WithPathCompression()),
+	} {
+		var keys = make([]string, 0, 100)
+		for i := 0; i < 100; i++ {
+			k := primitive.NewObjectID().Hex()
+			keys = append(keys, k)
+			// insert the uppercase form of every other key
+			if i%2 == 0 {
+				trie.Set([]byte(strings.ToUpper(k)), i)
+			} else {
+				trie.Set([]byte(k), i)
+			}
+		}
+		for i, k := range keys {
+			for _, q := range []string{k, strings.ToUpper(k)} {
+				if v, ok := trie.Get([]byte(q)); !ok || v != i {
+					t.Logf("get %s fails\n", q)
+					t.Fail()
+				}
+			}
+			trie.Set([]byte(strings.ToUpper(k)), i)
+		}
+		if trie.Len() != len(keys) {
+			t.Logf("size %d fails\n", trie.Len())
+			t.Fail()
+		}
+		sort.Strings(keys)
+		var i int
+		for k := range trie.All() {
+			if string(k) != keys[i] {
+				t.Logf("key %s-%s fails\n", k, keys[i])
+				t.Fail()
+			}
+			i++
+		}
+		q := []byte(strings.ToUpper(keys[50]))
+		if k, _, _ := trie.GtEntry(q); string(k) != keys[51] || trie.Rank(q) != 50 {
+			t.Logf("gt %s fails\n", q)
+			t.Fail()
+		}
+		if trie.CountPrefix(q[:4]) != trie.CountPrefix([]byte(keys[50][:4])) {
+			t.Logf("prefix %s fails\n", q[:4])
+			t.Fail()
+		}
+		if _, ok := trie.Del(q); !ok || trie.Len() != len(keys)-1 {
+			t.Logf("del %s fails\n", q)
+			t.Fail()
+		}
+	}
+	if err := NewTrie(24, NewHexMap[int]).TrySet([]byte("5F1A00000000000000000000"), 0); err == nil {
+		t.Log("uppercase key should fail without folding")
+		t.Fail()
+	}
 }
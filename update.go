@@ -0,0 +1,54 @@
+package trie
+
+// Update calls f with the current value of key, then stores the value f
+// returns, or deletes the entry when keep is false. f must not modify the
+// trie. Like Set, Update panics on an invalid key.
+func (t *Trie[V]) Update(key []byte, f func(old V, exists bool) (val V, keep bool)) {
+	if err := t.check(key); err != nil {
+		panic(err)
+	}
+	path, found := t.walk(key)
+	var old V
+	if found {
+		old = path[len(path)-1].val
+	}
+	val, keep := f(old, found)
+	switch {
+	case keep && found:
+		t.store(path[len(path)-1], val)
+	case keep:
+		t.insert(path, key, val)
+	case found:
+		t.remove(path)
+	}
+}
+
+// GetOrSet returns the value of key if present, otherwise it stores val.
+// loaded reports whether the value was already there.
+func (t *Trie[V]) GetOrSet(key []byte, val V) (actual V, loaded bool) {
+	if err := t.check(key); err != nil {
+		panic(err)
+	}
+	path, found := t.walk(key)
+	if found {
+		return path[len(path)-1].val, true
+	}
+	t.insert(path, key, val)
+	return val, false
+}
+
+// Swap stores val for key and returns the previous value, if any.
+func (t *Trie[V]) Swap(key []byte, val V) (old V, loaded bool) {
+	if err := t.check(key); err != nil {
+		panic(err)
+	}
+	path, found := t.walk(key)
+	if found {
+		node := path[len(path)-1]
+		old = node.val
+		t.store(node, val)
+		return old, true
+	}
+	t.insert(path, key, val)
+	return old, false
+}
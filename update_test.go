@@ -0,0 +1,81 @@
+package trie
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestUpdate(t *testing.T) {
+	var trie = NewTrie(3, NewNmap[int])
+	var incr = func(old int, exists bool) (int, bool) {
+		return old + 1, old < 2
+	}
+	for i := 0; i < 3; i++ {
+		trie.Update([]byte("123"), incr)
+	}
+	if v, ok := trie.Get([]byte("123")); ok || trie.Len() != 0 {
+		t.Logf("update to delete: %d fails\n", v)
+		t.Fail()
+	}
+	trie.Update([]byte("123"), incr)
+	trie.Update([]byte("124"), func(old int, exists bool) (int, bool) {
+		if exists {
+			t.Log("124 should not exist")
+			t.Fail()
+		}
+		return 0, false
+	})
+	if v, ok := trie.Get([]byte("123")); !ok || v != 1 || trie.Len() != 1 {
+		t.Logf("update: %d fails\n", v)
+		t.Fail()
+	}
+	if _, ok := trie.Get([]byte("124")); ok {
+		t.Log("update without keep should not insert")
+		t.Fail()
+	}
+}
+
+func TestGetOrSet(t *testing.T) {
+	var trie = NewVarTrie(NewByteMap[string])
+	if v, loaded := trie.GetOrSet([]byte("ab"), "x"); loaded || v != "x" {
+		t.Logf("get or set: %s fails\n", v)
+		t.Fail()
+	}
+	if v, loaded := trie.GetOrSet([]byte("ab"), "y"); !loaded || v != "x" {
+		t.Logf("get or set loaded: %s fails\n", v)
+		t.Fail()
+	}
+	if old, loaded := trie.Swap([]byte("a"), "z"); loaded || old != "" {
+		t.Logf("swap: %s fails\n", old)
+		t.Fail()
+	}
+	if old, loaded := trie.Swap([]byte("ab"), "w"); !loaded || old != "x" {
+		t.Logf("swap loaded: %s fails\n", old)
+		t.Fail()
+	}
+	if vals := trie.Scan(Unbounded(), Unbounded()); fmt.Sprint(vals) != "[z w]" || trie.Len() != 2 {
+		t.Logf("scan: %v fails\n", vals)
+		t.Fail()
+	}
+}
+
+func TestLookupAllocs(t *testing.T) {
+	var trie = NewTrie(12, NewByteMap[int])
+	var key = []byte("0123456789ab")
+	trie.Set(key, 0)
+	var cases = []struct {
+		name string
+		f    func()
+	}{
+		{"get", func() { trie.Get(key) }},
+		{"set", func() { trie.Set(key, 1) }},
+		{"get versioned", func() { trie.GetVersioned(key) }},
+		{"compare and swap", func() { trie.CompareAndSwap(key, trie.Version(), 2) }},
+	}
+	for _, c := range cases {
+		if n := testing.AllocsPerRun(100, c.f); n != 0 {
+			t.Logf("%s: %v allocs fails\n", c.name, n)
+			t.Fail()
+		}
+	}
+}
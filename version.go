@@ -0,0 +1,56 @@
+package trie
+
+// Version returns the trie-wide version, which grows on every change. Each
+// entry records the trie version of its last write, so entry versions are
+// never reused, not even after a delete.
+func (t *Trie[V]) Version() uint64 {
+	return t.version
+}
+
+// GetVersioned is like Get, but also returns the version of the entry.
+func (t *Trie[V]) GetVersioned(key []byte) (val V, version uint64, ok bool) {
+	if t.check(key) != nil {
+		return val, 0, false
+	}
+	node := t.find(key)
+	if node == nil {
+		return val, 0, false
+	}
+	return node.val, node.version, true
+}
+
+// CompareAndSwap stores val if the entry for key is still at version
+// expected. An expected version of 0 means the key must not exist.
+func (t *Trie[V]) CompareAndSwap(key []byte, expected uint64, val V) bool {
+	if t.check(key) != nil {
+		return false
+	}
+	node := t.find(key)
+	if node == nil {
+		if expected != 0 {
+			return false
+		}
+		path, _ := t.walk(key)
+		t.insert(path, key, val)
+		return true
+	}
+	if node.version != expected {
+		return false
+	}
+	t.store(node, val)
+	return true
+}
+
+// CompareAndDelete deletes the entry for key if it is still at version
+// expected.
+func (t *Trie[V]) CompareAndDelete(key []byte, expected uint64) bool {
+	if t.check(key) != nil {
+		return false
+	}
+	if node := t.find(key); node == nil || node.version != expected {
+		return false
+	}
+	path, _ := t.walk(key)
+	t.remove(path)
+	return true
+}
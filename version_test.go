@@ -0,0 +1,61 @@
+package trie
+
+import (
+	"testing"
+)
+
+func TestVersion(t *testing.T) {
+	var trie = NewTrie(3, NewNmap[string])
+	var key = []byte("123")
+	if trie.CompareAndSwap(key, 1, "a") {
+		t.Log("cas on missing key with version 1 should fail")
+		t.Fail()
+	}
+	if !trie.CompareAndSwap(key, 0, "a") {
+		t.Log("cas on missing key with version 0 fails")
+		t.Fail()
+	}
+	_, v1, ok := trie.GetVersioned(key)
+	if !ok || v1 == 0 || v1 != trie.Version() {
+		t.Logf("version %d fails\n", v1)
+		t.Fail()
+	}
+	if trie.CompareAndSwap(key, 0, "b") {
+		t.Log("cas on existing key with version 0 should fail")
+		t.Fail()
+	}
+
+	trie.Set([]byte("456"), "x")
+	if _, v, _ := trie.GetVersioned(key); v != v1 {
+		t.Log("writing another key should not change the version")
+		t.Fail()
+	}
+	if !trie.CompareAndSwap(key, v1, "b") {
+		t.Log("cas fails")
+		t.Fail()
+	}
+	val, v2, _ := trie.GetVersioned(key)
+	if val != "b" || v2 <= v1 {
+		t.Logf("cas: %s-%d fails\n", val, v2)
+		t.Fail()
+	}
+	if trie.CompareAndSwap(key, v1, "c") || trie.CompareAndDelete(key, v1) {
+		t.Log("stale version should fail")
+		t.Fail()
+	}
+
+	before := trie.Version()
+	if !trie.CompareAndDelete(key, v2) {
+		t.Log("cad fails")
+		t.Fail()
+	}
+	if _, _, ok := trie.GetVersioned(key); ok || trie.Version() <= before {
+		t.Log("cad should delete the key and move the version")
+		t.Fail()
+	}
+	trie.Set(key, "d")
+	if _, v3, _ := trie.GetVersioned(key); v3 <= v2 {
+		t.Logf("version %d after re-insert fails\n", v3)
+		t.Fail()
+	}
+}